@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/config"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
+	"github.com/grafchitaru/skillBuilder/internal/linkcheck"
+	"github.com/grafchitaru/skillBuilder/internal/storage/postgresql"
+	"github.com/urfave/cli/v2"
+)
+
+func linkCheckCommand(cfg config.Config) *cli.Command {
+	return &cli.Command{
+		Name:        "linkcheck",
+		Description: "Check every material link that is due and record broken ones",
+		Action: func(ctx *cli.Context) error {
+			storage, err := postgresql.New(cfg.PostgresDatabaseDsn)
+			if err != nil {
+				fmt.Println("failed to connect to postgres: %w", err)
+				return err
+			}
+			defer storage.Close()
+
+			checker := linkcheck.New(storage, importer.NewClient(cfg.ImportAllowPrivate), cfg.LinkCheckInterval, cfg.LinkCheckConcurrency)
+			checked, broken, err := checker.CheckAll(ctx.Context)
+			fmt.Printf("checked %d links, %d broken\n", checked, broken)
+
+			return err
+		},
+	}
+}
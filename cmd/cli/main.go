@@ -5,14 +5,26 @@ import (
 	"os"
 	"os/signal"
 
+	"github.com/grafchitaru/skillBuilder/internal/config"
 	"github.com/pressly/goose/v3"
 	"github.com/urfave/cli/v2"
 )
 
 func main() {
-	cliApp := &cli.App{
+	cliApp := newApp(*config.NewConfig())
+
+	if err := cliApp.Run(os.Args); err != nil {
+		fmt.Println("failed to run cli: %w", err)
+	}
+}
+
+// newApp registers every command. The config is built once by the caller, as building it
+// defines the global command-line flags.
+func newApp(cfg config.Config) *cli.App {
+	return &cli.App{
 		Commands: []*cli.Command{
-			migrateCommand(),
+			migrateCommand(cfg),
+			linkCheckCommand(cfg),
 		},
 		Before: func(c *cli.Context) error {
 			goose.SetBaseFS(os.DirFS(MigrationsDir))
@@ -22,8 +34,4 @@ func main() {
 			return nil
 		},
 	}
-
-	if err := cliApp.Run(os.Args); err != nil {
-		fmt.Println("failed to run cli: %w", err)
-	}
 }
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewApp(t *testing.T) {
+	app := newApp(config.Config{})
+
+	var names []string
+	for _, command := range app.Commands {
+		names = append(names, command.Name)
+	}
+	assert.Equal(t, []string{"migration", "linkcheck"}, names)
+
+	require.NoError(t, app.Run([]string{"cli", "help"}))
+}
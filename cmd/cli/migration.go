@@ -11,9 +11,8 @@ import (
 
 const MigrationsDir = "./migrations"
 
-func migrateCommand() *cli.Command {
+func migrateCommand(cfg config.Config) *cli.Command {
 	var nativeDB *sql.DB
-	cfg := *config.NewConfig()
 	return &cli.Command{
 		Name:        "migration",
 		Description: "Goose migration cli (https://github.com/pressly/goose)",
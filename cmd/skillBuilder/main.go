@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/alerts"
 	"github.com/grafchitaru/skillBuilder/internal/config"
+	"github.com/grafchitaru/skillBuilder/internal/feeds"
 	"github.com/grafchitaru/skillBuilder/internal/handlers"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
+	"github.com/grafchitaru/skillBuilder/internal/linkcheck"
+	"github.com/grafchitaru/skillBuilder/internal/ranking"
+	"github.com/grafchitaru/skillBuilder/internal/related"
 	"github.com/grafchitaru/skillBuilder/internal/server"
 	storage2 "github.com/grafchitaru/skillBuilder/internal/storage"
 	"github.com/grafchitaru/skillBuilder/internal/storage/postgresql"
@@ -22,5 +29,14 @@ func main() {
 
 	defer storage.Close()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go alerts.New(storage, cfg.SavedSearchInterval).Run(ctx)
+	go feeds.NewPoller(storage, importer.NewClient(cfg.ImportAllowPrivate), cfg.FeedPollInterval).Run(ctx)
+	go ranking.New(storage, cfg.RankingInterval, cfg.TrendingWindow, cfg.PopularWindow).Run(ctx)
+	go related.New(storage, cfg.RelatedInterval).Run(ctx)
+	go linkcheck.New(storage, importer.NewClient(cfg.ImportAllowPrivate), cfg.LinkCheckInterval, cfg.LinkCheckConcurrency).Run(ctx)
+
 	server.New(handlers.Handlers{Config: cfg, Repos: storage})
 }
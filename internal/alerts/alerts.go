@@ -0,0 +1,62 @@
+package alerts
+
+import (
+	"context"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"log"
+	"time"
+)
+
+type Repositories interface {
+	GetAllSavedSearches() ([]models.SavedSearch, error)
+	CheckSavedSearch(search models.SavedSearch) (int, error)
+}
+
+// Worker periodically evaluates saved searches and turns new matches into notifications.
+type Worker struct {
+	repos    Repositories
+	interval time.Duration
+}
+
+func New(repos Repositories, interval time.Duration) *Worker {
+	return &Worker{repos: repos, interval: interval}
+}
+
+// Run checks saved searches every interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.CheckAll(); err != nil {
+				log.Println("saved searches:", err)
+			}
+		}
+	}
+}
+
+// CheckAll checks every saved search once and returns the number of notifications created.
+// A failing search doesn't stop the others, the last error is returned.
+func (w *Worker) CheckAll() (int, error) {
+	searches, err := w.repos.GetAllSavedSearches()
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var lastErr error
+	for _, search := range searches {
+		n, err := w.repos.CheckSavedSearch(search)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		created += n
+	}
+
+	return created, lastErr
+}
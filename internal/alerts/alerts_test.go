@@ -0,0 +1,45 @@
+package alerts
+
+import (
+	"errors"
+	"github.com/grafchitaru/skillBuilder/internal/mocks"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCheckAll(t *testing.T) {
+	var checked []string
+	mockStorage := &mocks.MockStorage{
+		GetAllSavedSearchesFunc: func() ([]models.SavedSearch, error) {
+			return []models.SavedSearch{{Id: "search1"}, {Id: "search2"}, {Id: "search3"}}, nil
+		},
+		CheckSavedSearchFunc: func(search models.SavedSearch) (int, error) {
+			checked = append(checked, search.Id)
+			if search.Id == "search2" {
+				return 0, errors.New("check error")
+			}
+			return 2, nil
+		},
+	}
+
+	created, err := New(mockStorage, time.Minute).CheckAll()
+
+	assert.Error(t, err)
+	assert.Equal(t, 4, created)
+	assert.Equal(t, []string{"search1", "search2", "search3"}, checked)
+}
+
+func TestCheckAll_ListError(t *testing.T) {
+	mockStorage := &mocks.MockStorage{
+		GetAllSavedSearchesFunc: func() ([]models.SavedSearch, error) {
+			return nil, errors.New("list error")
+		},
+	}
+
+	created, err := New(mockStorage, time.Minute).CheckAll()
+
+	assert.Error(t, err)
+	assert.Zero(t, created)
+}
@@ -0,0 +1,171 @@
+package csvimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/tags"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const MaxRows = 1000
+
+const (
+	ColumnName        = "name"
+	ColumnDescription = "description"
+	ColumnType        = "type"
+	ColumnQuantity    = "quantity"
+	ColumnXp          = "xp"
+	ColumnLink        = "link"
+	ColumnTags        = "tags"
+)
+
+var ErrEmpty = errors.New("CSV has no header row")
+
+// Parse reads materials from CSV whose header row names the columns. A row gets its xp
+// from the xp column or, failing that, from quantity (default 1) times the xp of its type,
+// matched by name or id against types. Rows failing validation are reported as import errors.
+func Parse(data []byte, types []models.TypeMaterial) ([]models.NewMaterial, []models.ImportError, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, ErrEmpty
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{ColumnName, ColumnType} {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	materials := []models.NewMaterial{}
+	rowErrors := []models.ImportError{}
+	for rows := 0; ; {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if blank(record) {
+			continue
+		}
+		if rows++; rows > MaxRows {
+			return nil, nil, fmt.Errorf("more than %d rows", MaxRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		material, rowError := parseRow(field, types)
+		if rowError != nil {
+			rowError.Row = line
+			rowErrors = append(rowErrors, *rowError)
+			continue
+		}
+		materials = append(materials, material)
+	}
+
+	return materials, rowErrors, nil
+}
+
+func parseRow(field func(string) string, types []models.TypeMaterial) (models.NewMaterial, *models.ImportError) {
+	material := models.NewMaterial{
+		Name:        field(ColumnName),
+		Description: field(ColumnDescription),
+		Link:        field(ColumnLink),
+		Tags: tags.Normalize(strings.FieldsFunc(field(ColumnTags), func(r rune) bool {
+			return r == ',' || r == ';'
+		})),
+	}
+	if material.Name == "" {
+		return material, &models.ImportError{Column: ColumnName, Message: "name is required"}
+	}
+
+	if field(ColumnType) == "" {
+		return material, &models.ImportError{Column: ColumnType, Message: "type is required"}
+	}
+	typeMaterial, ok := findType(field(ColumnType), types)
+	if !ok {
+		return material, &models.ImportError{Column: ColumnType, Message: fmt.Sprintf("unknown type %q", field(ColumnType))}
+	}
+	material.TypeId = typeMaterial.Id
+
+	if value := field(ColumnXp); value != "" {
+		xp, err := strconv.Atoi(value)
+		if err != nil || xp < 0 {
+			return material, &models.ImportError{Column: ColumnXp, Message: "xp must be a non-negative integer"}
+		}
+		material.Xp = xp
+	} else {
+		quantity := 1
+		if value := field(ColumnQuantity); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return material, &models.ImportError{Column: ColumnQuantity, Message: "quantity must be a positive integer"}
+			}
+			quantity = n
+		}
+		material.Xp = quantity * typeMaterial.Xp
+	}
+
+	if material.Link != "" {
+		link, err := url.Parse(material.Link)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+			return material, &models.ImportError{Column: ColumnLink, Message: "link must be an http(s) URL"}
+		}
+	}
+
+	return material, nil
+}
+
+func findType(value string, types []models.TypeMaterial) (models.TypeMaterial, bool) {
+	for _, typeMaterial := range types {
+		if strings.EqualFold(typeMaterial.Name, value) || typeMaterial.Id == value {
+			return typeMaterial, true
+		}
+	}
+	return models.TypeMaterial{}, false
+}
+
+// delimiter guesses between comma and the semicolon used by spreadsheets in many locales.
+func delimiter(data []byte) rune {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func blank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package csvimport
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var types = []models.TypeMaterial{
+	{Id: "book", Name: "книга", Xp: 1},
+	{Id: "video", Name: "видеоролик", Xp: 10},
+}
+
+func TestParse(t *testing.T) {
+	data := "\ufeffName,Description,Type,Quantity,Link,Tags\n" +
+		"Go in Action,\"Intro, basics\",Книга,250,https://example.com/go,\"golang; Books\"\n" +
+		"\n" +
+		"Talk,,video,,,\n"
+
+	materials, rowErrors, err := Parse([]byte(data), types)
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Equal(t, []models.NewMaterial{
+		{Name: "Go in Action", Description: "Intro, basics", TypeId: "book", Xp: 250, Link: "https://example.com/go", Tags: []string{"golang", "books"}},
+		{Name: "Talk", TypeId: "video", Xp: 10, Tags: []string{}},
+	}, materials)
+}
+
+func TestParse_Semicolon(t *testing.T) {
+	materials, rowErrors, err := Parse([]byte("name;type;xp\nGo;книга;42\n"), types)
+	require.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	require.Len(t, materials, 1)
+	assert.Equal(t, 42, materials[0].Xp)
+}
+
+func TestParse_RowErrors(t *testing.T) {
+	data := "name,type,quantity,xp,link\n" +
+		",книга,,,\n" +
+		"A,podcast,,,\n" +
+		"B,книга,-1,,\n" +
+		"C,книга,,x,\n" +
+		"D,книга,,,ftp://example.com\n" +
+		"E,книга,,,\n"
+
+	materials, rowErrors, err := Parse([]byte(data), types)
+	require.NoError(t, err)
+	assert.Len(t, materials, 1)
+	assert.Equal(t, []models.ImportError{
+		{Row: 2, Column: ColumnName, Message: "name is required"},
+		{Row: 3, Column: ColumnType, Message: `unknown type "podcast"`},
+		{Row: 4, Column: ColumnQuantity, Message: "quantity must be a positive integer"},
+		{Row: 5, Column: ColumnXp, Message: "xp must be a non-negative integer"},
+		{Row: 6, Column: ColumnLink, Message: "link must be an http(s) URL"},
+	}, rowErrors)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, _, err := Parse(nil, types)
+	assert.ErrorIs(t, err, ErrEmpty)
+
+	_, _, err = Parse([]byte("name,link\nGo,\n"), types)
+	assert.Error(t, err)
+}
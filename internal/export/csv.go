@@ -0,0 +1,41 @@
+package export
+
+import (
+	"encoding/csv"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The header matches the columns understood by csvimport, so an export can be imported back.
+var csvHeader = []string{"name", "description", "type", "xp", "link", "tags", "completed"}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSV(w io.Writer) Encoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) Begin(models.Collection) error {
+	return e.w.Write(csvHeader)
+}
+
+func (e *csvEncoder) Write(row Row) error {
+	return e.w.Write([]string{
+		spreadsheetText(row.Material.Name),
+		spreadsheetText(row.Material.Description),
+		spreadsheetText(row.Type),
+		strconv.Itoa(row.Material.Xp),
+		spreadsheetText(row.Material.Link),
+		spreadsheetText(strings.Join(row.Material.Tags, "; ")),
+		strconv.FormatBool(row.Material.Completed),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"io"
+	"strings"
+)
+
+// Row is an exported material together with the name of its type.
+type Row struct {
+	Material models.Material
+	Type     string
+}
+
+// Encoder writes a collection and its materials to an output as they arrive.
+type Encoder interface {
+	Begin(collection models.Collection) error
+	Write(row Row) error
+	Close() error
+}
+
+type Format struct {
+	ContentType string
+	Extension   string
+	New         func(w io.Writer) Encoder
+}
+
+var Formats = map[string]Format{
+	"csv":  {ContentType: "text/csv; charset=utf-8", Extension: "csv", New: newCSV},
+	"json": {ContentType: "application/json", Extension: "json", New: newJSON},
+	"md":   {ContentType: "text/markdown; charset=utf-8", Extension: "md", New: newMarkdown},
+	"pdf":  {ContentType: "application/pdf", Extension: "pdf", New: newPDF},
+	"xlsx": {ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Extension: "xlsx", New: newXLSX},
+}
+
+// spreadsheetText prefixes text a spreadsheet would evaluate as a formula with a quote, so an
+// exported material name can't run one on the reader's machine.
+func spreadsheetText(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// Filename turns a collection name into a file name with the given extension.
+func Filename(name, extension string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = "collection"
+	}
+	return name + "." + extension
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+	"time"
+)
+
+var (
+	collection = models.Collection{Id: "collection1", Name: "Go: основы", Description: "Reading list", Tags: []string{"golang"}}
+	rows       = []Row{
+		{Material: models.Material{Id: "material1", Name: "Go in Action", Xp: 250, Link: "https://example.com/go", Tags: []string{"golang", "books"}, Completed: true}, Type: "книга"},
+		{Material: models.Material{Id: "material2", Name: "Talk <1>", Description: "Line one\nline two", Xp: 10}, Type: "видеоролик"},
+	}
+)
+
+func encode(t *testing.T, format string) []byte {
+	var buf bytes.Buffer
+	encoder := Formats[format].New(&buf)
+	require.NoError(t, encoder.Begin(collection))
+	for _, row := range rows {
+		require.NoError(t, encoder.Write(row))
+	}
+	require.NoError(t, encoder.Close())
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	records, err := csv.NewReader(bytes.NewReader(encode(t, "csv"))).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		csvHeader,
+		{"Go in Action", "", "книга", "250", "https://example.com/go", "golang; books", "true"},
+		{"Talk <1>", "Line one\nline two", "видеоролик", "10", "", "", "false"},
+	}, records)
+}
+
+func TestCSV_Formula(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := Formats["csv"].New(&buf)
+	require.NoError(t, encoder.Begin(collection))
+	require.NoError(t, encoder.Write(Row{Material: models.Material{Name: "=HYPERLINK(\"http://evil\")", Description: "@SUM(A1)", Tags: []string{"-1+1"}}, Type: "+book"}))
+	require.NoError(t, encoder.Close())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"'=HYPERLINK(\"http://evil\")", "'@SUM(A1)", "'+book", "0", "", "'-1+1", "false"}, records[1])
+}
+
+func TestJSON(t *testing.T) {
+	var result struct {
+		Collection jsonCollection `json:"collection"`
+		Materials  []jsonMaterial `json:"materials"`
+	}
+	require.NoError(t, json.Unmarshal(encode(t, "json"), &result))
+	assert.Equal(t, "Go: основы", result.Collection.Name)
+	require.Len(t, result.Materials, 2)
+	assert.Equal(t, "книга", result.Materials[0].Type)
+	assert.True(t, result.Materials[0].Completed)
+}
+
+func TestMarkdown(t *testing.T) {
+	assert.Equal(t, "# Go: основы\n\nReading list\n\n`golang`\n\n"+
+		"- [x] [Go in Action](<https://example.com/go>) · книга · 250 XP · `golang` `books`\n"+
+		"- [ ] Talk \\<1> · видеоролик · 10 XP\n  Line one line two\n", string(encode(t, "md")))
+}
+
+func TestXLSX(t *testing.T) {
+	data := encode(t, "xlsx")
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[file.Name] = string(content)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files["xl/workbook.xml"], `name="Go основы"`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="D2"><v>250</v></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="G2" t="b"><v>1</v></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"], `Talk &lt;1&gt;`)
+}
+
+func TestSpreadsheetText(t *testing.T) {
+	for _, text := range []string{"=1+1", "+1", "-1", "@SUM(A1)", "\tcmd", "\rcmd"} {
+		assert.Equal(t, "'"+text, spreadsheetText(text))
+	}
+	assert.Equal(t, "Go in Action", spreadsheetText("Go in Action"))
+	assert.Equal(t, "", spreadsheetText(""))
+}
+
+func TestFilename(t *testing.T) {
+	assert.Equal(t, "Go_ основы.csv", Filename("Go: основы", "csv"))
+	assert.Equal(t, "collection.md", Filename("  ", "md"))
+}
+
+func TestPDF(t *testing.T) {
+	data := encode(t, "pdf")
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+}
+
+func TestProgressReport(t *testing.T) {
+	var buf bytes.Buffer
+	err := ProgressReport(&buf, []models.Collection{
+		{Name: "Go: основы", SumXp: sql.NullInt64{Int64: 100, Valid: true}, Xp: sql.NullInt64{Int64: 40, Valid: true}},
+		{Name: "Empty"},
+	}, time.Date(2024, 10, 19, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"encoding/json"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"io"
+)
+
+type jsonCollection struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+}
+
+type jsonMaterial struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Xp          int      `json:"xp"`
+	Link        string   `json:"link"`
+	Tags        []string `json:"tags"`
+	Completed   bool     `json:"completed"`
+}
+
+// jsonEncoder writes {"collection": ..., "materials": [...]} one material at a time.
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func newJSON(w io.Writer) Encoder {
+	return &jsonEncoder{w: w}
+}
+
+func (e *jsonEncoder) Begin(collection models.Collection) error {
+	data, err := json.Marshal(jsonCollection{
+		Id:          collection.Id,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Tags:        collection.Tags,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(e.w, `{"collection":`+string(data)+`,"materials":[`)
+	return err
+}
+
+func (e *jsonEncoder) Write(row Row) error {
+	data, err := json.Marshal(jsonMaterial{
+		Id:          row.Material.Id,
+		Name:        row.Material.Name,
+		Description: row.Material.Description,
+		Type:        row.Type,
+		Xp:          row.Material.Xp,
+		Link:        row.Material.Link,
+		Tags:        row.Material.Tags,
+		Completed:   row.Material.Completed,
+	})
+	if err != nil {
+		return err
+	}
+	if e.count > 0 {
+		data = append([]byte(","), data...)
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	_, err := io.WriteString(e.w, "]}\n")
+	return err
+}
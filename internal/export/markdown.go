@@ -0,0 +1,72 @@
+package export
+
+import (
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"io"
+	"strings"
+)
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "#", `\#`, "|", `\|`, "<", `\<`)
+
+// markdownEncoder writes the collection as a task list, checked for completed materials.
+type markdownEncoder struct {
+	w io.Writer
+}
+
+func newMarkdown(w io.Writer) Encoder {
+	return &markdownEncoder{w: w}
+}
+
+func (e *markdownEncoder) Begin(collection models.Collection) error {
+	text := "# " + markdownLine(collection.Name) + "\n\n"
+	if description := strings.TrimSpace(collection.Description); description != "" {
+		text += markdownEscaper.Replace(description) + "\n\n"
+	}
+	if len(collection.Tags) > 0 {
+		text += markdownTags(collection.Tags) + "\n\n"
+	}
+	_, err := io.WriteString(e.w, text)
+	return err
+}
+
+func (e *markdownEncoder) Write(row Row) error {
+	check := " "
+	if row.Material.Completed {
+		check = "x"
+	}
+
+	title := markdownLine(row.Material.Name)
+	if row.Material.Link != "" {
+		title = fmt.Sprintf("[%s](<%s>)", title, strings.ReplaceAll(row.Material.Link, ">", "%3E"))
+	}
+
+	text := fmt.Sprintf("- [%s] %s · %s · %d XP", check, title, markdownLine(row.Type), row.Material.Xp)
+	if len(row.Material.Tags) > 0 {
+		text += " · " + markdownTags(row.Material.Tags)
+	}
+	text += "\n"
+	if description := markdownLine(row.Material.Description); description != "" {
+		text += "  " + description + "\n"
+	}
+
+	_, err := io.WriteString(e.w, text)
+	return err
+}
+
+func (e *markdownEncoder) Close() error {
+	return nil
+}
+
+// markdownLine escapes text and folds it onto a single line.
+func markdownLine(text string) string {
+	return markdownEscaper.Replace(strings.Join(strings.Fields(text), " "))
+}
+
+func markdownTags(names []string) string {
+	tags := make([]string, len(names))
+	for i, name := range names {
+		tags[i] = "`" + name + "`"
+	}
+	return strings.Join(tags, " ")
+}
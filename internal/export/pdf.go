@@ -0,0 +1,141 @@
+package export
+
+import (
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pdf"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	titleStyle   = pdf.Style{Size: 20, Bold: true}
+	headingStyle = pdf.Style{Size: 13, Bold: true}
+	bodyStyle    = pdf.Style{Size: 11}
+	noteStyle    = pdf.Style{Size: 9, Gray: true, Indent: 16}
+)
+
+// pdfEncoder renders a syllabus with the materials grouped by type. A PDF needs the whole
+// layout before it can be written, so rows are collected until Close.
+type pdfEncoder struct {
+	w          io.Writer
+	collection models.Collection
+	rows       []Row
+}
+
+func newPDF(w io.Writer) Encoder {
+	return &pdfEncoder{w: w}
+}
+
+func (e *pdfEncoder) Begin(collection models.Collection) error {
+	e.collection = collection
+	return nil
+}
+
+func (e *pdfEncoder) Write(row Row) error {
+	e.rows = append(e.rows, row)
+	return nil
+}
+
+func (e *pdfEncoder) Close() error {
+	doc, err := pdf.New(e.collection.Name)
+	if err != nil {
+		return err
+	}
+
+	doc.Paragraph(e.collection.Name, titleStyle)
+	if description := strings.TrimSpace(e.collection.Description); description != "" {
+		doc.Space(4)
+		doc.Paragraph(description, bodyStyle)
+	}
+	if len(e.collection.Tags) > 0 {
+		doc.Paragraph("#"+strings.Join(e.collection.Tags, " #"), pdf.Style{Size: 9, Gray: true})
+	}
+
+	doc.Space(8)
+	total, done := e.collection.SumXp.Int64, e.collection.Xp.Int64
+	doc.Columns(fmt.Sprintf("Materials: %d", len(e.rows)), fmt.Sprintf("%d / %d XP", done, total), bodyStyle)
+	doc.ProgressBar(fraction(done, total), 0)
+
+	groups := map[string][]Row{}
+	for _, row := range e.rows {
+		name := row.Type
+		if name == "" {
+			name = "—"
+		}
+		groups[name] = append(groups[name], row)
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rows := groups[name]
+		xp := 0
+		for _, row := range rows {
+			xp += row.Material.Xp
+		}
+
+		doc.Space(12)
+		doc.Columns(name, fmt.Sprintf("%d · %d XP", len(rows), xp), headingStyle)
+		doc.Rule()
+		for _, row := range rows {
+			check := "☐"
+			if row.Material.Completed {
+				check = "☑"
+			}
+			doc.Columns(check+" "+row.Material.Name, fmt.Sprintf("%d XP", row.Material.Xp), bodyStyle)
+			if row.Material.Link != "" {
+				doc.Paragraph(row.Material.Link, noteStyle)
+			}
+			if description := strings.TrimSpace(row.Material.Description); description != "" {
+				doc.Paragraph(description, noteStyle)
+			}
+			doc.Space(3)
+		}
+	}
+
+	_, err = doc.WriteTo(e.w)
+	return err
+}
+
+// ProgressReport renders the progress of a user through their collections.
+func ProgressReport(w io.Writer, collections []models.Collection, generated time.Time) error {
+	doc, err := pdf.New("Progress report")
+	if err != nil {
+		return err
+	}
+
+	var total, done int64
+	for _, collection := range collections {
+		total += collection.SumXp.Int64
+		done += collection.Xp.Int64
+	}
+
+	doc.Paragraph("Progress report", titleStyle)
+	doc.Paragraph(generated.Format("2006-01-02"), pdf.Style{Size: 9, Gray: true})
+	doc.Space(8)
+	doc.Columns(fmt.Sprintf("Collections: %d", len(collections)), fmt.Sprintf("%d / %d XP", done, total), headingStyle)
+	doc.ProgressBar(fraction(done, total), 0)
+
+	for _, collection := range collections {
+		doc.Space(12)
+		doc.Columns(collection.Name, fmt.Sprintf("%d / %d XP · %.0f%%", collection.Xp.Int64, collection.SumXp.Int64,
+			100*fraction(collection.Xp.Int64, collection.SumXp.Int64)), bodyStyle)
+		doc.ProgressBar(fraction(collection.Xp.Int64, collection.SumXp.Int64), 0)
+	}
+
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+func fraction(done, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(done) / float64(total)
+}
@@ -0,0 +1,208 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"html"
+	"strings"
+)
+
+// MaxSummary caps the length of an entry summary, in runes.
+const MaxSummary = 1000
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+var ErrNotFeed = errors.New("not an RSS or Atom feed")
+
+// Channel is a parsed feed with its entries in document order, usually newest first.
+type Channel struct {
+	Title   string
+	Entries []Entry
+}
+
+type Entry struct {
+	GUID    string
+	Title   string
+	Link    string
+	Summary string
+	Author  string
+}
+
+// Key identifies the entry within its feed: the GUID, or failing that the link or title.
+func (e Entry) Key() string {
+	for _, key := range []string{e.GUID, e.Link, e.Title} {
+		if key != "" {
+			return key
+		}
+	}
+	return ""
+}
+
+type rssDocument struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	// RSS 1.0 puts items next to the channel rather than inside it.
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string    `xml:"title"`
+	Links       []rssLink `xml:"link"`
+	GUID        string    `xml:"guid"`
+	Description string    `xml:"description"`
+	Author      string    `xml:"author"`
+	Creator     string    `xml:"http://purl.org/dc/elements/1.1/ creator"`
+}
+
+// rssLink also matches atom:link elements some feeds add to items, which carry an href instead.
+type rssLink struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Id      string     `xml:"id"`
+	Title   string     `xml:"title"`
+	Links   []atomLink `xml:"link"`
+	Summary string     `xml:"summary"`
+	Content string     `xml:"content"`
+	Authors []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// Parse reads RSS 0.9x/1.0/2.0 and Atom feeds. Summaries are reduced to plain text.
+func Parse(data []byte) (Channel, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	switch root {
+	case "rss", "RDF":
+		var document rssDocument
+		if err := decode(data, &document); err != nil {
+			return Channel{}, err
+		}
+		channel := Channel{Title: clean(document.Channel.Title), Entries: []Entry{}}
+		for _, item := range append(document.Channel.Items, document.Items...) {
+			entry := Entry{
+				GUID:    strings.TrimSpace(item.GUID),
+				Title:   clean(item.Title),
+				Summary: summary(item.Description),
+				Author:  clean(item.Creator),
+			}
+			if entry.Author == "" {
+				entry.Author = clean(item.Author)
+			}
+			for _, link := range item.Links {
+				if link.XMLName.Space != atomNamespace && strings.TrimSpace(link.Value) != "" {
+					entry.Link = strings.TrimSpace(link.Value)
+					break
+				}
+			}
+			channel.Entries = append(channel.Entries, entry)
+		}
+		return channel, nil
+	case "feed":
+		var feed atomFeed
+		if err := decode(data, &feed); err != nil {
+			return Channel{}, err
+		}
+		channel := Channel{Title: clean(feed.Title), Entries: []Entry{}}
+		for _, item := range feed.Entries {
+			entry := Entry{
+				GUID:    strings.TrimSpace(item.Id),
+				Title:   clean(item.Title),
+				Summary: summary(item.Summary),
+			}
+			if entry.Summary == "" {
+				entry.Summary = summary(item.Content)
+			}
+			if len(item.Authors) > 0 {
+				entry.Author = clean(item.Authors[0].Name)
+			}
+			for _, link := range item.Links {
+				if link.Rel == "" || link.Rel == "alternate" {
+					entry.Link = strings.TrimSpace(link.Href)
+					break
+				}
+			}
+			channel.Entries = append(channel.Entries, entry)
+		}
+		return channel, nil
+	}
+	return Channel{}, ErrNotFeed
+}
+
+// rootElement returns the local name of the document element.
+func rootElement(data []byte) (string, error) {
+	decoder := newDecoder(data)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", ErrNotFeed
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func decode(data []byte, target any) error {
+	if err := newDecoder(data).Decode(target); err != nil {
+		return errors.Join(ErrNotFeed, err)
+	}
+	return nil
+}
+
+// newDecoder tolerates the HTML entities found in real-world feeds. Unlike in HTML, <link>
+// isn't empty in RSS, so tags aren't closed automatically.
+func newDecoder(data []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	return decoder
+}
+
+func clean(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// summary strips markup from an HTML description and shortens it to MaxSummary runes.
+func summary(value string) string {
+	var text strings.Builder
+	for {
+		start := strings.IndexByte(value, '<')
+		if start < 0 {
+			text.WriteString(value)
+			break
+		}
+		text.WriteString(value[:start])
+		text.WriteByte(' ')
+		end := strings.IndexByte(value[start:], '>')
+		if end < 0 {
+			break
+		}
+		value = value[start+end+1:]
+	}
+
+	plain := []rune(clean(html.UnescapeString(text.String())))
+	if len(plain) > MaxSummary {
+		return strings.TrimSpace(string(plain[:MaxSummary-1])) + "…"
+	}
+	return string(plain)
+}
@@ -0,0 +1,104 @@
+package feeds
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:atom="http://www.w3.org/2005/Atom">
+<channel>
+  <title>Go Blog</title>
+  <atom:link href="https://go.dev/blog/feed.rss" rel="self"/>
+  <item>
+    <title>Range over  functions</title>
+    <link>https://go.dev/blog/range-functions</link>
+    <atom:link href="https://example.com/other"/>
+    <guid isPermaLink="false">tag:go.dev,2024:range</guid>
+    <description>&lt;p&gt;Iterators &amp;amp; more&amp;nbsp;in Go&lt;/p&gt;</description>
+    <dc:creator>Ian</dc:creator>
+  </item>
+  <item>
+    <title>Go 1.23</title>
+    <link>https://go.dev/blog/go1.23</link>
+    <author>team@go.dev (Go team)</author>
+  </item>
+</channel>
+</rss>`
+
+const atomFeedXML = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Engineering</title>
+  <entry>
+    <id>urn:uuid:1</id>
+    <title>Scaling Postgres</title>
+    <link rel="replies" href="https://example.com/scaling#comments"/>
+    <link href="https://example.com/scaling"/>
+    <content type="html">&lt;b&gt;Shards&lt;/b&gt; and replicas</content>
+    <author><name>Ann</name></author>
+  </entry>
+</feed>`
+
+func TestParseRSS(t *testing.T) {
+	channel, err := Parse([]byte(rssFeed))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Go Blog", channel.Title)
+	assert.Equal(t, []Entry{
+		{
+			GUID:    "tag:go.dev,2024:range",
+			Title:   "Range over functions",
+			Link:    "https://go.dev/blog/range-functions",
+			Summary: "Iterators & more in Go",
+			Author:  "Ian",
+		},
+		{
+			Title:  "Go 1.23",
+			Link:   "https://go.dev/blog/go1.23",
+			Author: "team@go.dev (Go team)",
+		},
+	}, channel.Entries)
+	assert.Equal(t, "https://go.dev/blog/go1.23", channel.Entries[1].Key())
+}
+
+func TestParseRSS1(t *testing.T) {
+	channel, err := Parse([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/">
+		<channel><title>Old school</title></channel>
+		<item><title>First</title><link>https://example.com/1</link></item>
+	</rdf:RDF>`))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Old school", channel.Title)
+	require.Len(t, channel.Entries, 1)
+	assert.Equal(t, "https://example.com/1", channel.Entries[0].Link)
+}
+
+func TestParseAtom(t *testing.T) {
+	channel, err := Parse([]byte(atomFeedXML))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Engineering", channel.Title)
+	assert.Equal(t, []Entry{{
+		GUID:    "urn:uuid:1",
+		Title:   "Scaling Postgres",
+		Link:    "https://example.com/scaling",
+		Summary: "Shards and replicas",
+		Author:  "Ann",
+	}}, channel.Entries)
+}
+
+func TestParseNotFeed(t *testing.T) {
+	for _, data := range []string{"<html><body>Hi</body></html>", "not xml at all", ""} {
+		_, err := Parse([]byte(data))
+		assert.ErrorIs(t, err, ErrNotFeed, data)
+	}
+}
+
+func TestSummaryTruncates(t *testing.T) {
+	text := summary(strings.Repeat("a", MaxSummary+10))
+
+	assert.Len(t, []rune(text), MaxSummary)
+	assert.True(t, strings.HasSuffix(text, "…"))
+}
@@ -0,0 +1,189 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// MaxFeedSize caps how much of a feed is downloaded.
+const MaxFeedSize = 5 << 20
+
+// MaxEntries caps how many entries one check adds, so subscribing to a long feed doesn't
+// flood the collection with its archive.
+const MaxEntries = 20
+
+// ErrNotEditor is recorded for a feed whose subscriber was removed from its collection or
+// demoted below editor, the poller no longer adds materials on their behalf.
+var ErrNotEditor = errors.New("subscriber can no longer edit the collection")
+
+type Repositories interface {
+	GetAllFeeds() ([]models.Feed, error)
+	GetCollectionRole(collectionID, userID string) (string, error)
+	GetTypeMaterials(page models.Page) (models.TypeMaterialList, error)
+	AddFeedEntries(feed models.Feed, entries []models.FeedEntry) (int, error)
+	UpdateFeedStatus(feedID, title, lastError string) error
+}
+
+// Fetch downloads and parses the feed at link.
+func Fetch(ctx context.Context, client *http.Client, link string) (Channel, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return Channel{}, err
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+	req.Header.Set("User-Agent", "SkillBuilder/1.0 (+feed reader)")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, importer.ErrForbiddenAddress) {
+			return Channel{}, importer.ErrForbiddenAddress
+		}
+		return Channel{}, fmt.Errorf("fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Channel{}, fmt.Errorf("fetch feed: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxFeedSize+1))
+	if err != nil {
+		return Channel{}, fmt.Errorf("fetch feed: %w", err)
+	}
+	if len(data) > MaxFeedSize {
+		return Channel{}, importer.ErrTooLarge
+	}
+
+	return Parse(data)
+}
+
+// Store adds the channel's entries not seen before in the feed as articles of its collection,
+// oldest first, and returns how many were added.
+func Store(repos Repositories, feed models.Feed, channel Channel) (int, error) {
+	types, err := repos.GetTypeMaterials(models.Page{Limit: pagination.MaxLimit})
+	if err != nil {
+		return 0, err
+	}
+
+	// Feeds list entries newest first; skipping the keyless ones keeps the rest identifiable.
+	var keys []string
+	var items []importer.Item
+	entries := channel.Entries[:min(len(channel.Entries), MaxEntries)]
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.Key() == "" {
+			continue
+		}
+		name := entry.Title
+		if name == "" {
+			name = entry.Link
+		}
+		keys = append(keys, entry.Key())
+		items = append(items, importer.Item{
+			Name:        name,
+			Description: entry.Summary,
+			Author:      entry.Author,
+			Link:        entry.Link,
+			Type:        importer.TypeArticle,
+			Quantity:    1,
+		})
+	}
+
+	materials, err := importer.Materials(items, types.TypeMaterials)
+	if err != nil {
+		return 0, err
+	}
+
+	feedEntries := make([]models.FeedEntry, len(materials))
+	for i, material := range materials {
+		feedEntries[i] = models.FeedEntry{Key: keys[i], Material: material}
+	}
+	return repos.AddFeedEntries(feed, feedEntries)
+}
+
+// Poller periodically fetches every feed and adds its new entries to the feed's collection.
+type Poller struct {
+	repos    Repositories
+	client   *http.Client
+	interval time.Duration
+}
+
+func NewPoller(repos Repositories, client *http.Client, interval time.Duration) *Poller {
+	return &Poller{repos: repos, client: client, interval: interval}
+}
+
+// Run checks feeds every interval until ctx is cancelled.
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.CheckAll(ctx); err != nil {
+				log.Println("feeds:", err)
+			}
+		}
+	}
+}
+
+// CheckAll checks every feed once and returns the number of materials created. A failing feed
+// is recorded in its status and doesn't stop the others.
+func (p *Poller) CheckAll(ctx context.Context) (int, error) {
+	feeds, err := p.repos.GetAllFeeds()
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var lastErr error
+	for _, feed := range feeds {
+		if ctx.Err() != nil {
+			return created, ctx.Err()
+		}
+
+		n, err := p.Check(ctx, feed)
+		created += n
+		if err != nil {
+			lastErr = err
+		}
+	}
+	return created, lastErr
+}
+
+// Check fetches one feed, stores its new entries and records the outcome in the feed's status.
+// Entries are stored as the subscriber's materials, so the subscriber must still be an editor.
+func (p *Poller) Check(ctx context.Context, feed models.Feed) (int, error) {
+	var channel Channel
+	role, err := p.repos.GetCollectionRole(feed.CollectionId, feed.UserId)
+	if err == nil && !roles.Can(role, roles.Editor) {
+		err = ErrNotEditor
+	}
+	if err == nil {
+		channel, err = Fetch(ctx, p.client, feed.URL)
+	}
+	n := 0
+	if err == nil {
+		n, err = Store(p.repos, feed, channel)
+	}
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	if statusErr := p.repos.UpdateFeedStatus(feed.Id, channel.Title, message); statusErr != nil {
+		return n, statusErr
+	}
+	return n, err
+}
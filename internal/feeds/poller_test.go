@@ -0,0 +1,127 @@
+package feeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
+	"github.com/grafchitaru/skillBuilder/internal/mocks"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type feedStatus struct {
+	title, lastError string
+}
+
+func newFeedStorage(feeds []models.Feed, added map[string][]models.FeedEntry, statuses map[string]feedStatus) *mocks.MockStorage {
+	return &mocks.MockStorage{
+		GetAllFeedsFunc: func() ([]models.Feed, error) {
+			return feeds, nil
+		},
+		GetCollectionRoleFunc: func(collectionID, userID string) (string, error) {
+			return "editor", nil
+		},
+		GetTypeMaterialsFunc: func(page models.Page) (models.TypeMaterialList, error) {
+			return models.TypeMaterialList{TypeMaterials: []models.TypeMaterial{{Id: "article", Name: "статья", Xp: 3}}}, nil
+		},
+		AddFeedEntriesFunc: func(feed models.Feed, entries []models.FeedEntry) (int, error) {
+			added[feed.Id] = entries
+			return len(entries), nil
+		},
+		UpdateFeedStatusFunc: func(feedID, title, lastError string) error {
+			statuses[feedID] = feedStatus{title, lastError}
+			return nil
+		},
+	}
+}
+
+func TestPollerCheckAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rss" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, rssFeed)
+	}))
+	defer server.Close()
+
+	added := map[string][]models.FeedEntry{}
+	statuses := map[string]feedStatus{}
+	mockStorage := newFeedStorage([]models.Feed{
+		{Id: "feed1", URL: server.URL + "/rss"},
+		{Id: "feed2", URL: server.URL + "/gone"},
+	}, added, statuses)
+
+	created, err := NewPoller(mockStorage, importer.NewClient(true), time.Minute).CheckAll(context.Background())
+
+	assert.EqualError(t, err, "fetch feed: unexpected status 404")
+	assert.Equal(t, 2, created)
+
+	// Entries are added oldest first.
+	require.Len(t, added["feed1"], 2)
+	assert.Equal(t, "https://go.dev/blog/go1.23", added["feed1"][0].Key)
+	assert.Equal(t, "tag:go.dev,2024:range", added["feed1"][1].Key)
+	material := added["feed1"][1].Material
+	assert.Equal(t, "Range over functions", material.Name)
+	assert.Equal(t, "article", material.TypeId)
+	assert.Equal(t, 3, material.Xp)
+	assert.Equal(t, "Iterators & more in Go\n\nAuthor: Ian", material.Description)
+
+	assert.Equal(t, feedStatus{title: "Go Blog"}, statuses["feed1"])
+	assert.Equal(t, feedStatus{lastError: "fetch feed: unexpected status 404"}, statuses["feed2"])
+}
+
+func TestPollerRefusesPrivateAddresses(t *testing.T) {
+	added := map[string][]models.FeedEntry{}
+	statuses := map[string]feedStatus{}
+	mockStorage := newFeedStorage([]models.Feed{{Id: "feed1", URL: "http://127.0.0.1:1/rss"}}, added, statuses)
+
+	_, err := NewPoller(mockStorage, importer.NewClient(false), time.Minute).CheckAll(context.Background())
+
+	assert.ErrorIs(t, err, importer.ErrForbiddenAddress)
+	assert.Equal(t, importer.ErrForbiddenAddress.Error(), statuses["feed1"].lastError)
+}
+
+func TestPollerCheck_NotEditor(t *testing.T) {
+	fetched := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = true
+		fmt.Fprint(w, rssFeed)
+	}))
+	defer server.Close()
+
+	added := map[string][]models.FeedEntry{}
+	statuses := map[string]feedStatus{}
+	mockStorage := newFeedStorage(nil, added, statuses)
+	mockStorage.GetCollectionRoleFunc = func(collectionID, userID string) (string, error) {
+		return "viewer", nil
+	}
+
+	feed := models.Feed{Id: "feed1", CollectionId: "collection1", UserId: "user1", URL: server.URL}
+	created, err := NewPoller(mockStorage, importer.NewClient(true), time.Minute).Check(context.Background(), feed)
+
+	assert.ErrorIs(t, err, ErrNotEditor)
+	assert.Zero(t, created)
+	assert.False(t, fetched)
+	assert.Empty(t, added)
+	assert.Equal(t, ErrNotEditor.Error(), statuses["feed1"].lastError)
+}
+
+func TestPollerCheckAll_ListError(t *testing.T) {
+	mockStorage := &mocks.MockStorage{
+		GetAllFeedsFunc: func() ([]models.Feed, error) {
+			return nil, errors.New("list error")
+		},
+	}
+
+	created, err := NewPoller(mockStorage, importer.NewClient(true), time.Minute).CheckAll(context.Background())
+
+	assert.Error(t, err)
+	assert.Zero(t, created)
+}
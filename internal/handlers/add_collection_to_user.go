@@ -21,6 +21,13 @@ func (ctx *Handlers) AddCollectionToUser(res http.ResponseWriter, req *http.Requ
 		return
 	}
 
+	// Private collections are only joined through an invitation.
+	_, err = ctx.Repos.GetCollection(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+
 	err = ctx.Repos.AddCollectionToUser(userID, collectionID)
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusInternalServerError)
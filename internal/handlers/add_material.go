@@ -3,8 +3,14 @@ package handlers
 import (
 	"compress/gzip"
 	"encoding/json"
+	"errors"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
 	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"github.com/grafchitaru/skillBuilder/internal/storage"
+	"github.com/grafchitaru/skillBuilder/internal/tags"
 	"io"
 	"net/http"
 )
@@ -43,16 +49,35 @@ func (ctx *Handlers) AddMaterial(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	collection, err := ctx.Repos.GetCollection(material.CollectionID, userID)
+	role, err := ctx.Repos.GetCollectionRole(material.CollectionID, userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(res, "Collection not found", http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if collection.UserId != userID {
+	if !roles.Can(role, roles.Editor) {
 		http.Error(res, "Forbidden", http.StatusForbidden)
 		return
 	}
 
+	if material.ISBN != "" {
+		book, err := importer.NewOpenLibrary(ctx.Config.OpenLibraryURL).Book(req.Context(), material.ISBN)
+		if err != nil {
+			bookError(res, err)
+			return
+		}
+
+		types, err := ctx.Repos.GetTypeMaterials(models.Page{Limit: pagination.MaxLimit})
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		fillFromBook(&material, book, types.TypeMaterials)
+	}
+
 	id, err := ctx.Repos.CreateMaterial(userID, material.Name, material.Description, material.TypeId, material.Xp, material.Link)
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusInternalServerError)
@@ -65,6 +90,14 @@ func (ctx *Handlers) AddMaterial(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if names := tags.Normalize(material.Tags); len(names) > 0 {
+		err = ctx.Repos.SetMaterialTags(id, names)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	result := models.ResultId{
 		Id: id,
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
 	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/tags"
 	"io"
 	"net/http"
 )
@@ -55,6 +56,22 @@ func (ctx *Handlers) CreateCollection(res http.ResponseWriter, req *http.Request
 		return
 	}
 
+	if collection.IsPublic != nil && !*collection.IsPublic {
+		err = ctx.Repos.SetCollectionVisibility(id, false)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if names := tags.Normalize(collection.Tags); len(names) > 0 {
+		err = ctx.Repos.SetCollectionTags(id, names)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
 	result := models.ResultId{
 		Id: id,
 	}
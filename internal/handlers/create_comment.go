@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/storage"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func (ctx *Handlers) CreateComment(res http.ResponseWriter, req *http.Request) {
+	materialID := chi.URLParam(req, "id")
+	if materialID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(reader)
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var comment models.NewComment
+
+	if err := json.Unmarshal(body, &comment); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	comment.Text = strings.TrimSpace(comment.Text)
+	if comment.Text == "" {
+		http.Error(res, "Text is required", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	_, err = ctx.Repos.GetMaterial(materialID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if comment.ParentId != "" {
+		parent, err := ctx.Repos.GetComment(comment.ParentId)
+		if err != nil || parent.MaterialId != materialID {
+			http.Error(res, "Parent comment not found", http.StatusBadRequest)
+			return
+		}
+	}
+
+	id, err := ctx.Repos.CreateComment(materialID, userID, comment.ParentId, comment.Text)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := models.ResultId{
+		Id: id,
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusCreated)
+	res.Write(data)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/feeds"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type FeedRequest struct {
+	URL string `json:"url"`
+}
+
+func (ctx *Handlers) CreateFeed(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	if collectionID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(reader)
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var feedRequest FeedRequest
+	if err := json.Unmarshal(body, &feedRequest); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	link, err := url.Parse(strings.TrimSpace(feedRequest.URL))
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+		http.Error(res, "Invalid feed URL", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !roles.Can(role, roles.Editor) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	// Fetching the feed up front rejects links that aren't feeds and fills the collection
+	// right away instead of on the next poll.
+	channel, err := feeds.Fetch(req.Context(), importer.NewClient(ctx.Config.ImportAllowPrivate), link.String())
+	switch {
+	case errors.Is(err, importer.ErrForbiddenAddress):
+		http.Error(res, "Link points to a private address", http.StatusBadRequest)
+		return
+	case errors.Is(err, feeds.ErrNotFeed):
+		http.Error(res, feeds.ErrNotFeed.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, importer.ErrTooLarge):
+		http.Error(res, "Feed too large", http.StatusUnprocessableEntity)
+		return
+	case err != nil:
+		http.Error(res, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	id, err := ctx.Repos.CreateFeed(collectionID, userID, link.String(), channel.Title)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	feed := models.Feed{Id: id, CollectionId: collectionID, UserId: userID}
+	if _, err = feeds.Store(ctx.Repos, feed, channel); err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err = ctx.Repos.UpdateFeedStatus(id, channel.Title, ""); err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := models.ResultId{
+		Id: id,
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusCreated)
+	res.Write(data)
+}
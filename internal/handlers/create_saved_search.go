@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type SavedSearchRequest struct {
+	Name   string    `json:"name"`
+	Search TextQuery `json:"search"`
+}
+
+func (ctx *Handlers) CreateSavedSearch(res http.ResponseWriter, req *http.Request) {
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(reader)
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var saved SavedSearchRequest
+
+	if err := json.Unmarshal(body, &saved); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	search, err := saved.Search.searchQuery()
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	name := strings.TrimSpace(saved.Name)
+	if name == "" {
+		name = search.Query
+	}
+	if name == "" {
+		http.Error(res, "Name is required", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	id, err := ctx.Repos.CreateSavedSearch(userID, name, search)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := models.ResultId{
+		Id: id,
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusCreated)
+	res.Write(data)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"net/http"
+)
+
+func (ctx *Handlers) DeleteCollaborator(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	collaboratorID := chi.URLParam(req, "userID")
+	if collectionID == "" || collaboratorID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	// Collaborators may always leave a collection on their own, others are removed only by
+	// someone ranking above them.
+	if collaboratorID != userID {
+		collaboratorRole, err := ctx.Repos.GetCollectionRole(collectionID, collaboratorID)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !roles.Can(role, roles.Maintainer) || !roles.Outranks(role, collaboratorRole) {
+			http.Error(res, "Forbidden", http.StatusForbidden)
+			return
+		}
+	}
+
+	err = ctx.Repos.DeleteCollaborator(collectionID, collaboratorID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+}
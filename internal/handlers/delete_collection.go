@@ -3,6 +3,7 @@ package handlers
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
 	"net/http"
 )
 
@@ -19,7 +20,17 @@ func (ctx *Handlers) DeleteCollection(res http.ResponseWriter, req *http.Request
 		return
 	}
 
-	err = ctx.Repos.DeleteCollection(userID, collectionID)
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !roles.Can(role, roles.Owner) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	err = ctx.Repos.DeleteCollection(collectionID)
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusInternalServerError)
 		return
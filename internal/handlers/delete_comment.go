@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"net/http"
+)
+
+func (ctx *Handlers) DeleteComment(res http.ResponseWriter, req *http.Request) {
+	commentID := chi.URLParam(req, "id")
+	if commentID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	comment, err := ctx.Repos.GetComment(commentID)
+	if err != nil || comment.Deleted {
+		http.Error(res, "Comment not found", http.StatusNotFound)
+		return
+	}
+
+	if comment.UserId == userID {
+		err = ctx.Repos.DeleteComment(commentID)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Maintainers of a collection containing the material moderate its discussion.
+	role, err := ctx.Repos.GetMaterialRole(comment.MaterialId, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !roles.Can(role, roles.Maintainer) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	err = ctx.Repos.HideComment(commentID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"net/http"
+)
+
+func (ctx *Handlers) DeleteFeed(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	feedID := chi.URLParam(req, "feedID")
+	if collectionID == "" || feedID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !roles.Can(role, roles.Editor) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	err = ctx.Repos.DeleteFeed(feedID, collectionID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
 	"net/http"
 )
 
@@ -19,7 +20,17 @@ func (ctx *Handlers) DeleteMaterial(res http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	err = ctx.Repos.DeleteMaterial(userID, materialID)
+	role, err := ctx.Repos.GetMaterialRole(materialID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !roles.Can(role, roles.Maintainer) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	err = ctx.Repos.DeleteMaterial(materialID)
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusInternalServerError)
 		return
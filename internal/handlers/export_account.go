@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"mime"
+	"net/http"
+)
+
+func (ctx *Handlers) ExportAccount(res http.ResponseWriter, req *http.Request) {
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	archive, err := ctx.Repos.GetAccountArchive(userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "skillbuilder-archive-" + archive.ExportedAt.Format("2006-01-02") + ".json",
+	}))
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/export"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"mime"
+	"net/http"
+)
+
+func (ctx *Handlers) ExportCollection(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	if collectionID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	name := req.URL.Query().Get("format")
+	if name == "" {
+		name = "csv"
+	}
+	format, ok := export.Formats[name]
+	if !ok {
+		http.Error(res, "Unknown format", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := ctx.Repos.GetCollection(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	types, err := ctx.Repos.GetTypeMaterials(models.Page{Limit: pagination.MaxLimit})
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	typeNames := make(map[string]string, len(types.TypeMaterials))
+	for _, typeMaterial := range types.TypeMaterials {
+		typeNames[typeMaterial.Id] = typeMaterial.Name
+	}
+
+	// The document is built in memory and sent only once complete, so that a failing page or
+	// encoder, PDF renders everything on Close, still gets an error status.
+	var buf bytes.Buffer
+	encoder := format.New(&buf)
+	if err = encoder.Begin(collection); err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	filter := models.MaterialFilter{Page: models.Page{Limit: pagination.MaxLimit}}
+	for {
+		materials, err := ctx.Repos.GetMaterials(collectionID, userID, filter)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, material := range materials.Materials {
+			if err = encoder.Write(export.Row{Material: material, Type: typeNames[material.TypeId]}); err != nil {
+				http.Error(res, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if materials.NextCursor == "" {
+			break
+		}
+		filter.Cursor = materials.NextCursor
+	}
+	if err = encoder.Close(); err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", format.ContentType)
+	res.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": export.Filename(collection.Name, format.Extension),
+	}))
+	res.WriteHeader(http.StatusOK)
+	res.Write(buf.Bytes())
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"io"
+	"net/http"
+)
+
+func (ctx *Handlers) ForkCollection(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	if collectionID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(reader)
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var fork models.ForkCollection
+
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &fork); err != nil {
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	_, err = ctx.Repos.GetCollection(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	id, err := ctx.Repos.ForkCollection(userID, collectionID, fork.KeepProgress)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := models.ResultId{
+		Id: id,
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusCreated)
+	res.Write(data)
+}
@@ -7,6 +7,9 @@ import (
 	"net/http"
 )
 
+// maxRelatedCollections caps the related collections shown with a collection.
+const maxRelatedCollections = 5
+
 func (ctx *Handlers) GetCollection(res http.ResponseWriter, req *http.Request) {
 	collectionID := chi.URLParam(req, "id")
 	if collectionID == "" {
@@ -26,6 +29,12 @@ func (ctx *Handlers) GetCollection(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	result.Related, err = ctx.Repos.GetRelatedCollections(collectionID, userID, maxRelatedCollections)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	data, err := json.Marshal(result)
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusInternalServerError)
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/grafchitaru/skillBuilder/internal/mocks"
@@ -24,6 +25,10 @@ func TestGetCollection(t *testing.T) {
 				UserId:      userID,
 			}, nil
 		},
+		GetRelatedCollectionsFunc: func(collectionID, userID string, limit int) ([]models.RelatedCollection, error) {
+			assert.Equal(t, "a97ae726-3859-4a1f-85ec-22452c243ac5", collectionID)
+			return []models.RelatedCollection{{Id: "related1", Name: "Related Collection", Score: 0.8, CommonUsers: 3}}, nil
+		},
 	}
 
 	mockAuthService := mocks.NewMockAuthService()
@@ -53,7 +58,12 @@ func TestGetCollection(t *testing.T) {
 
 	r.ServeHTTP(rr, req)
 
-	assert.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var collection models.Collection
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &collection))
+	require.Len(t, collection.Related, 1)
+	assert.Equal(t, "related1", collection.Related[0].Id)
 }
 
 func TestGetCollection_IDNotFound(t *testing.T) {
@@ -2,8 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/tags"
 	"net/http"
+	"strconv"
 )
 
 func (ctx *Handlers) GetCollections(res http.ResponseWriter, req *http.Request) {
@@ -13,7 +18,22 @@ func (ctx *Handlers) GetCollections(res http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	result, err := ctx.Repos.GetCollections(userID)
+	page, err := parsePage(req, models.CollectionSorts)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.CollectionFilter{
+		Tags: tags.Normalize(req.URL.Query()["tag"]),
+		Page: page,
+	}
+
+	result, err := ctx.Repos.GetCollections(userID, filter)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		http.Error(res, pagination.ErrInvalidCursor.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusNotFound)
 		return
@@ -29,3 +49,24 @@ func (ctx *Handlers) GetCollections(res http.ResponseWriter, req *http.Request)
 	res.Header().Set("Content-Type", "application/json")
 	res.Write(data)
 }
+
+// parsePage reads the sort, limit and cursor query parameters of a list request.
+func parsePage(req *http.Request, sorts []string) (models.Page, error) {
+	page := models.Page{
+		Sort:   req.URL.Query().Get("sort"),
+		Cursor: req.URL.Query().Get("cursor"),
+	}
+	if !models.ValidSort(page.Sort, sorts) {
+		return models.Page{}, errors.New("Unknown sort")
+	}
+
+	if value := req.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return models.Page{}, errors.New("Invalid limit")
+		}
+		page.Limit = n
+	}
+
+	return page, nil
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/storage"
+	"net/http"
+)
+
+func (ctx *Handlers) GetComments(res http.ResponseWriter, req *http.Request) {
+	materialID := chi.URLParam(req, "id")
+	if materialID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	// Comments have a single order, so only limit and cursor apply.
+	page, err := parsePage(req, nil)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	_, err = ctx.Repos.GetMaterial(materialID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	result, err := ctx.Repos.GetComments(materialID, userID, page)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		http.Error(res, pagination.ErrInvalidCursor.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/tags"
 	"net/http"
 )
 
@@ -20,7 +24,22 @@ func (ctx *Handlers) GetMaterials(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	result, err := ctx.Repos.GetMaterials(collectionID, userID)
+	page, err := parsePage(req, models.MaterialSorts)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filter := models.MaterialFilter{
+		Tags: tags.Normalize(req.URL.Query()["tag"]),
+		Page: page,
+	}
+
+	result, err := ctx.Repos.GetMaterials(collectionID, userID, filter)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		http.Error(res, pagination.ErrInvalidCursor.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusNotFound)
 		return
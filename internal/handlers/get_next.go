@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/planner"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (ctx *Handlers) GetNext(res http.ResponseWriter, req *http.Request) {
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	limit := 0
+	if value := req.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			http.Error(res, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	candidates, err := ctx.Repos.GetNextCandidates(userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(planner.Plan(candidates, time.Now(), pagination.Limit(limit)))
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/grafchitaru/skillBuilder/internal/export"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"mime"
+	"net/http"
+	"time"
+)
+
+func (ctx *Handlers) GetProgressReport(res http.ResponseWriter, req *http.Request) {
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var collections []models.Collection
+	page := models.Page{Limit: pagination.MaxLimit}
+	for {
+		result, err := ctx.Repos.GetUserCollections(userID, page)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		collections = append(collections, result.Collections...)
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+
+	now := time.Now()
+	var data bytes.Buffer
+	if err = export.ProgressReport(&data, collections, now); err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/pdf")
+	res.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "progress-report-" + now.Format("2006-01-02") + ".pdf",
+	}))
+	res.WriteHeader(http.StatusOK)
+	res.Write(data.Bytes())
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"net/http"
+)
+
+func (ctx *Handlers) GetTrendingCollections(res http.ResponseWriter, req *http.Request) {
+	ctx.getRankedCollections(res, req, models.RankingTrending)
+}
+
+func (ctx *Handlers) GetPopularCollections(res http.ResponseWriter, req *http.Request) {
+	ctx.getRankedCollections(res, req, models.RankingPopular)
+}
+
+func (ctx *Handlers) getRankedCollections(res http.ResponseWriter, req *http.Request, kind string) {
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// Rankings have a single order, so only limit and cursor apply.
+	page, err := parsePage(req, nil)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := ctx.Repos.GetRankedCollections(kind, userID, page)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		http.Error(res, pagination.ErrInvalidCursor.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
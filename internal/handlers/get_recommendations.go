@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/recommend"
+	"net/http"
+	"strconv"
+)
+
+func (ctx *Handlers) GetRecommendations(res http.ResponseWriter, req *http.Request) {
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	limit := 0
+	if value := req.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			http.Error(res, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+	limit = pagination.Limit(limit)
+
+	corpus, err := ctx.Repos.GetRecommendationCorpus(userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	recommendations := models.Recommendations{
+		Collections: recommend.Recommend(corpus.Completed, corpus.Collections, limit),
+		Materials:   recommend.Recommend(corpus.Completed, corpus.Materials, limit),
+	}
+
+	data, err := json.Marshal(recommendations)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
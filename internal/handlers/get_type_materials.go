@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
 	"net/http"
 )
 
@@ -13,7 +16,17 @@ func (ctx *Handlers) GetTypeMaterials(res http.ResponseWriter, req *http.Request
 		return
 	}
 
-	result, err := ctx.Repos.GetTypeMaterials()
+	page, err := parsePage(req, models.MaterialSorts)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := ctx.Repos.GetTypeMaterials(page)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		http.Error(res, pagination.ErrInvalidCursor.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusNotFound)
 		return
@@ -17,8 +17,8 @@ func TestGetTypeMaterials(t *testing.T) {
 	cfg := mocks.NewConfig()
 	testUserID := "af02d036-b457-43a1-8fc9-5c640c3f7d2a"
 	mockStorage := &mocks.MockStorage{
-		GetTypeMaterialsFunc: func() ([]models.TypeMaterial, error) {
-			return []models.TypeMaterial{
+		GetTypeMaterialsFunc: func(page models.Page) (models.TypeMaterialList, error) {
+			return models.TypeMaterialList{TypeMaterials: []models.TypeMaterial{
 				{
 					Id:             "type1",
 					CreatedAt:      time.Now(),
@@ -35,7 +35,7 @@ func TestGetTypeMaterials(t *testing.T) {
 					Characteristic: "Test",
 					Xp:             50,
 				},
-			}, nil
+			}}, nil
 		},
 	}
 
@@ -72,8 +72,8 @@ func TestGetTypeMaterials(t *testing.T) {
 func TestGetTypeMaterials_Unauthorized(t *testing.T) {
 	cfg := mocks.NewConfig()
 	mockStorage := &mocks.MockStorage{
-		GetTypeMaterialsFunc: func() ([]models.TypeMaterial, error) {
-			return []models.TypeMaterial{}, nil
+		GetTypeMaterialsFunc: func(page models.Page) (models.TypeMaterialList, error) {
+			return models.TypeMaterialList{}, nil
 		},
 	}
 
@@ -106,8 +106,8 @@ func TestGetTypeMaterials_NotFound(t *testing.T) {
 	cfg := mocks.NewConfig()
 	testUserID := "af02d036-b457-43a1-8fc9-5c640c3f7d2a"
 	mockStorage := &mocks.MockStorage{
-		GetTypeMaterialsFunc: func() ([]models.TypeMaterial, error) {
-			return []models.TypeMaterial{}, errors.New("type materials not found")
+		GetTypeMaterialsFunc: func(page models.Page) (models.TypeMaterialList, error) {
+			return models.TypeMaterialList{}, errors.New("type materials not found")
 		},
 	}
 
@@ -18,8 +18,8 @@ func TestGetUserCollections(t *testing.T) {
 	cfg := mocks.NewConfig()
 	testUserID := "af02d036-b457-43a1-8fc9-5c640c3f7d2a"
 	mockStorage := &mocks.MockStorage{
-		GetUserCollectionsFunc: func(userID string) ([]models.Collection, error) {
-			return []models.Collection{
+		GetUserCollectionsFunc: func(userID string, page models.Page) (models.CollectionList, error) {
+			return models.CollectionList{Collections: []models.Collection{
 				{
 					Id:          "collection1",
 					CreatedAt:   time.Now(),
@@ -40,7 +40,7 @@ func TestGetUserCollections(t *testing.T) {
 					SumXp:       sql.NullInt64{Int64: 200, Valid: true},
 					Xp:          sql.NullInt64{Int64: 100, Valid: true},
 				},
-			}, nil
+			}}, nil
 		},
 	}
 
@@ -77,8 +77,8 @@ func TestGetUserCollections(t *testing.T) {
 func TestGetUserCollections_Unauthorized(t *testing.T) {
 	cfg := mocks.NewConfig()
 	mockStorage := &mocks.MockStorage{
-		GetUserCollectionsFunc: func(userID string) ([]models.Collection, error) {
-			return []models.Collection{}, nil
+		GetUserCollectionsFunc: func(userID string, page models.Page) (models.CollectionList, error) {
+			return models.CollectionList{}, nil
 		},
 	}
 
@@ -111,8 +111,8 @@ func TestGetUserCollections_InternalServerError(t *testing.T) {
 	cfg := mocks.NewConfig()
 	testUserID := "af02d036-b457-43a1-8fc9-5c640c3f7d2a"
 	mockStorage := &mocks.MockStorage{
-		GetUserCollectionsFunc: func(userID string) ([]models.Collection, error) {
-			return []models.Collection{}, errors.New("internal server error")
+		GetUserCollectionsFunc: func(userID string, page models.Page) (models.CollectionList, error) {
+			return models.CollectionList{}, errors.New("internal server error")
 		},
 	}
 
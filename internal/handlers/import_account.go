@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"io"
+	"net/http"
+)
+
+const maxArchiveSize = 50 << 20
+
+func (ctx *Handlers) ImportAccount(res http.ResponseWriter, req *http.Request) {
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(io.LimitReader(reader, maxArchiveSize+1))
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxArchiveSize {
+		http.Error(res, "File too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var archive models.Archive
+	if err := json.Unmarshal(body, &archive); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if archive.Version != models.ArchiveVersion {
+		http.Error(res, fmt.Sprintf("Unsupported archive version %d", archive.Version), http.StatusBadRequest)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	result, err := ctx.Repos.ImportAccountArchive(userID, archive)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"io"
+	"net/http"
+)
+
+func (ctx *Handlers) courseProviders() importer.CourseProviders {
+	return importer.CourseProviders{
+		importer.NewStepik(ctx.Config.StepikAPIURL),
+	}
+}
+
+func (ctx *Handlers) ImportCourse(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	if collectionID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(reader)
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var link importLinkRequest
+	if err := json.Unmarshal(body, &link); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !roles.Can(role, roles.Editor) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	course, err := ctx.courseProviders().Fetch(req.Context(), link.URL)
+	switch {
+	case errors.Is(err, importer.ErrUnsupported):
+		http.Error(res, "Unsupported course link", http.StatusBadRequest)
+		return
+	case errors.Is(err, importer.ErrNotFound):
+		http.Error(res, "Course not found", http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(res, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	types, err := ctx.Repos.GetTypeMaterials(models.Page{Limit: pagination.MaxLimit})
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	materials, err := importer.Materials(course.Items(), types.TypeMaterials)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := models.ImportResult{
+		DryRun:   req.URL.Query().Get("dry_run") == "true",
+		Rows:     len(materials),
+		Imported: []string{},
+		Errors:   []models.ImportError{},
+	}
+	if !result.DryRun {
+		result.Section, result.Imported, err = ctx.Repos.ImportSection(collectionID, userID, course.Title, materials)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
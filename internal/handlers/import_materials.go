@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/csvimport"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"io"
+	"net/http"
+)
+
+const maxImportSize = 5 << 20
+
+func (ctx *Handlers) ImportMaterials(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	if collectionID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(io.LimitReader(reader, maxImportSize+1))
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxImportSize {
+		http.Error(res, "File too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !roles.Can(role, roles.Editor) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	types, err := ctx.Repos.GetTypeMaterials(models.Page{Limit: pagination.MaxLimit})
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	materials, rowErrors, err := csvimport.Parse(body, types.TypeMaterials)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := models.ImportResult{
+		DryRun:   req.URL.Query().Get("dry_run") == "true",
+		Rows:     len(materials),
+		Imported: []string{},
+		Errors:   rowErrors,
+	}
+
+	status := http.StatusOK
+	switch {
+	case result.DryRun:
+	case len(rowErrors) > 0:
+		// Nothing is imported until every row is valid.
+		status = http.StatusUnprocessableEntity
+	default:
+		result.Imported, err = ctx.Repos.ImportMaterials(collectionID, userID, materials)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	res.Write(data)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"io"
+	"net/http"
+)
+
+func (ctx *Handlers) InviteCollaborator(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	if collectionID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(reader)
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var collaborator models.NewCollaborator
+
+	if err := json.Unmarshal(body, &collaborator); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !roles.Assignable(collaborator.Role) {
+		http.Error(res, "Unknown role", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !roles.Can(role, roles.Maintainer) || !roles.Outranks(role, collaborator.Role) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	collaboratorID, err := ctx.Repos.GetUser(collaborator.Login)
+	if err != nil {
+		http.Error(res, "User not found", http.StatusNotFound)
+		return
+	}
+
+	collaboratorRole, err := ctx.Repos.GetCollectionRole(collectionID, collaboratorID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if collaboratorRole == roles.Owner {
+		http.Error(res, "Conflict", http.StatusConflict)
+		return
+	}
+	// A maintainer can't demote or re-grant a fellow maintainer.
+	if !roles.Outranks(role, collaboratorRole) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	err = ctx.Repos.AddCollaborator(collectionID, collaboratorID, collaborator.Role)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := models.ResultId{
+		Id: collaboratorID,
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
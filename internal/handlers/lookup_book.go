@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"net/http"
+	"strings"
+)
+
+func (ctx *Handlers) LookupBook(res http.ResponseWriter, req *http.Request) {
+	isbn := chi.URLParam(req, "isbn")
+	if isbn == "" {
+		http.Error(res, "ISBN not found", http.StatusNotFound)
+		return
+	}
+
+	book, err := importer.NewOpenLibrary(ctx.Config.OpenLibraryURL).Book(req.Context(), isbn)
+	if err != nil {
+		bookError(res, err)
+		return
+	}
+
+	data, err := json.Marshal(book)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
+
+func bookError(res http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, importer.ErrInvalidISBN):
+		http.Error(res, "Invalid ISBN", http.StatusBadRequest)
+	case errors.Is(err, importer.ErrNotFound):
+		http.Error(res, "Book not found", http.StatusNotFound)
+	default:
+		http.Error(res, err.Error(), http.StatusBadGateway)
+	}
+}
+
+// fillFromBook completes the fields of a new material left empty from the book's metadata.
+// Unless given, the material is a book worth the xp of its type per page.
+func fillFromBook(material *models.NewMaterial, book models.Book, types []models.TypeMaterial) {
+	if material.Name == "" {
+		material.Name = book.Title
+	}
+	if material.Description == "" {
+		material.Description = book.Description
+		if len(book.Authors) > 0 {
+			material.Description = strings.TrimSpace("Authors: " + strings.Join(book.Authors, ", ") + "\n\n" + material.Description)
+		}
+	}
+	if material.Link == "" {
+		material.Link = book.Link
+	}
+
+	for _, typeMaterial := range types {
+		if material.TypeId == "" && strings.EqualFold(typeMaterial.Name, importer.TypeBook) {
+			material.TypeId = typeMaterial.Id
+		}
+		if typeMaterial.Id == material.TypeId && material.Xp == 0 {
+			material.Xp = book.Pages * typeMaterial.Xp
+		}
+	}
+}
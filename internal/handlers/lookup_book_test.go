@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/mocks"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newFakeOpenLibraryAPI(t *testing.T) *httptest.Server {
+	responses := map[string]string{
+		"/isbn/9780134190440.json": `{"title":"The Go Programming Language","number_of_pages":380,"description":"Go.","authors":[{"key":"/authors/OL1A"}]}`,
+		"/authors/OL1A.json":       `{"name":"Alan Donovan"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func serveLookupBook(t *testing.T, isbn string) *httptest.ResponseRecorder {
+	cfg := mocks.NewConfig()
+	cfg.OpenLibraryURL = newFakeOpenLibraryAPI(t).URL
+
+	hc := &Handlers{
+		Config: *cfg,
+		Repos:  &mocks.MockStorage{},
+		Auth:   mocks.NewMockAuthService(),
+	}
+
+	r := chi.NewRouter()
+	r.Get("/api/book/{isbn}", hc.LookupBook)
+
+	req, err := http.NewRequest("GET", "/api/book/"+isbn, nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestLookupBook(t *testing.T) {
+	rr := serveLookupBook(t, "978-0134190440")
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var book models.Book
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &book))
+	assert.Equal(t, "The Go Programming Language", book.Title)
+	assert.Equal(t, []string{"Alan Donovan"}, book.Authors)
+	assert.Equal(t, 380, book.Pages)
+}
+
+func TestLookupBook_NotFound(t *testing.T) {
+	rr := serveLookupBook(t, "9780306406157")
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestLookupBook_InvalidISBN(t *testing.T) {
+	rr := serveLookupBook(t, "12345")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
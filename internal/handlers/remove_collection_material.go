@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"github.com/grafchitaru/skillBuilder/internal/storage"
+	"net/http"
+)
+
+// RemoveCollectionMaterial unlinks a material from the collection without deleting it,
+// which is how a fork drops materials it shares with its origin.
+func (ctx *Handlers) RemoveCollectionMaterial(res http.ResponseWriter, req *http.Request) {
+	collectionID := chi.URLParam(req, "id")
+	materialID := chi.URLParam(req, "materialID")
+	if collectionID == "" || materialID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !roles.Can(role, roles.Editor) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	err = ctx.Repos.RemoveMaterialFromCollection(collectionID, materialID)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.Error(res, "Material not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+}
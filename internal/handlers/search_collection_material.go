@@ -3,19 +3,73 @@ package handlers
 import (
 	"compress/gzip"
 	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
 	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"github.com/grafchitaru/skillBuilder/internal/tags"
 	"io"
 	"net/http"
+	"strings"
 )
 
 type TextQuery struct {
-	Query string `json:"query"`
+	Query     string   `json:"query"`
+	Tags      []string `json:"tags"`
+	TypeId    string   `json:"type_id"`
+	MinXp     *int     `json:"min_xp"`
+	MaxXp     *int     `json:"max_xp"`
+	OwnerId   string   `json:"owner_id"`
+	Completed *bool    `json:"completed"`
+	Joined    *bool    `json:"joined"`
+	Sort      string   `json:"sort"`
+	Limit     int      `json:"limit"`
+	Cursor    string   `json:"cursor"`
 }
 
 type SearchResult struct {
-	Collections []models.Collection `json:"collections"`
-	Materials   []models.Material   `json:"materials"`
+	Collections []models.Collection  `json:"collections"`
+	Materials   []models.Material    `json:"materials"`
+	Facets      *models.SearchFacets `json:"facets,omitempty"`
+	NextCursor  string               `json:"next_cursor,omitempty"`
+}
+
+// searchCursor holds the keyset cursors of both result lists, which are paged together.
+// Once the first page is served, an empty cursor means that list has no more results.
+type searchCursor struct {
+	Collections string `json:"c,omitempty"`
+	Materials   string `json:"m,omitempty"`
+}
+
+// searchQuery validates the filters of a search request.
+func (query TextQuery) searchQuery() (models.SearchQuery, error) {
+	if !models.ValidSearchSort(query.Sort) {
+		return models.SearchQuery{}, errors.New("Unknown sort")
+	}
+	if query.Limit < 0 {
+		return models.SearchQuery{}, errors.New("Invalid limit")
+	}
+	if query.MinXp != nil && query.MaxXp != nil && *query.MinXp > *query.MaxXp {
+		return models.SearchQuery{}, errors.New("min_xp is greater than max_xp")
+	}
+	for _, id := range []string{query.TypeId, query.OwnerId} {
+		if _, err := uuid.Parse(id); id != "" && err != nil {
+			return models.SearchQuery{}, errors.New("Invalid ID")
+		}
+	}
+
+	return models.SearchQuery{
+		Query:     strings.TrimSpace(query.Query),
+		Tags:      tags.Normalize(query.Tags),
+		TypeId:    query.TypeId,
+		MinXp:     query.MinXp,
+		MaxXp:     query.MaxXp,
+		OwnerId:   query.OwnerId,
+		Completed: query.Completed,
+		Joined:    query.Joined,
+		Page:      models.Page{Sort: query.Sort, Limit: query.Limit},
+	}, nil
 }
 
 func (ctx *Handlers) SearchCollectionMaterial(res http.ResponseWriter, req *http.Request) {
@@ -52,21 +106,66 @@ func (ctx *Handlers) SearchCollectionMaterial(res http.ResponseWriter, req *http
 		return
 	}
 
-	resultCollections, err := ctx.Repos.SearchCollections(query.Query, userID)
+	search, err := query.searchQuery()
 	if err != nil {
-		http.Error(res, err.Error(), http.StatusNotFound)
+		http.Error(res, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	resultMaterials, err := ctx.Repos.SearchMaterials(query.Query)
-	if err != nil {
-		http.Error(res, err.Error(), http.StatusNotFound)
+	var cursor searchCursor
+	if err := pagination.Decode(query.Cursor, &cursor); err != nil {
+		http.Error(res, pagination.ErrInvalidCursor.Error(), http.StatusBadRequest)
 		return
 	}
 
 	searchResult := SearchResult{
-		Collections: resultCollections,
-		Materials:   resultMaterials,
+		Collections: []models.Collection{},
+		Materials:   []models.Material{},
+	}
+	var next searchCursor
+
+	if query.Cursor == "" || cursor.Collections != "" {
+		search.Cursor = cursor.Collections
+		resultCollections, err := ctx.Repos.SearchCollections(search, userID)
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			http.Error(res, pagination.ErrInvalidCursor.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusNotFound)
+			return
+		}
+		searchResult.Collections = resultCollections.Collections
+		next.Collections = resultCollections.NextCursor
+	}
+
+	if query.Cursor == "" || cursor.Materials != "" {
+		search.Cursor = cursor.Materials
+		resultMaterials, err := ctx.Repos.SearchMaterials(search, userID)
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			http.Error(res, pagination.ErrInvalidCursor.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusNotFound)
+			return
+		}
+		searchResult.Materials = resultMaterials.Materials
+		next.Materials = resultMaterials.NextCursor
+	}
+
+	if next != (searchCursor{}) {
+		searchResult.NextCursor = pagination.Encode(next)
+	}
+
+	// Facets describe the whole result set, so they are only returned with the first page.
+	if query.Cursor == "" {
+		facets, err := ctx.Repos.SearchFacets(search, userID)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		searchResult.Facets = &facets
 	}
 
 	resultData, err := json.Marshal(searchResult)
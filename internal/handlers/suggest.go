@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	suggestMinLength    = 2
+	defaultSuggestLimit = 5
+	maxSuggestLimit     = 10
+)
+
+func (ctx *Handlers) Suggest(res http.ResponseWriter, req *http.Request) {
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	limit := defaultSuggestLimit
+	if value := req.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			http.Error(res, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxSuggestLimit)
+	}
+
+	result := models.Suggestions{Titles: []models.Suggestion{}, Tags: []string{}}
+
+	// Single letters match nearly everything, so they are answered without a query.
+	query := strings.TrimSpace(req.URL.Query().Get("q"))
+	if utf8.RuneCountInString(query) >= suggestMinLength {
+		result, err = ctx.Repos.Suggest(query, userID, limit)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
 	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
 	"io"
 	"net/http"
 )
@@ -50,8 +51,17 @@ func (ctx *Handlers) UpdateCollection(res http.ResponseWriter, req *http.Request
 		return
 	}
 
+	role, err := ctx.Repos.GetCollectionRole(collectionID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !roles.Can(role, roles.Maintainer) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
 	collection.Id = collectionID
-	collection.UserId = userID
 	err = ctx.Repos.UpdateCollection(collection)
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusInternalServerError)
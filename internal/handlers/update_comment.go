@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func (ctx *Handlers) UpdateComment(res http.ResponseWriter, req *http.Request) {
+	commentID := chi.URLParam(req, "id")
+	if commentID == "" {
+		http.Error(res, "ID not found", http.StatusNotFound)
+		return
+	}
+
+	var reader io.Reader
+
+	if req.Header.Get(`Content-Encoding`) == `gzip` {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		reader = gz
+		defer gz.Close()
+	} else {
+		reader = req.Body
+	}
+
+	body, ioError := io.ReadAll(reader)
+	if ioError != nil {
+		http.Error(res, ioError.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var comment models.NewComment
+
+	if err := json.Unmarshal(body, &comment); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	comment.Text = strings.TrimSpace(comment.Text)
+	if comment.Text == "" {
+		http.Error(res, "Text is required", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := auth.GetUserID(req, ctx.Config.SecretKey)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	existing, err := ctx.Repos.GetComment(commentID)
+	if err != nil || existing.Deleted {
+		http.Error(res, "Comment not found", http.StatusNotFound)
+		return
+	}
+	if existing.UserId != userID {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	err = ctx.Repos.UpdateComment(commentID, comment.Text)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := models.ResultId{
+		Id: commentID,
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	res.Write(data)
+}
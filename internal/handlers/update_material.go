@@ -6,6 +6,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/grafchitaru/skillBuilder/internal/middlewares/auth"
 	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
 	"io"
 	"net/http"
 )
@@ -50,8 +51,17 @@ func (ctx *Handlers) UpdateMaterial(res http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	role, err := ctx.Repos.GetMaterialRole(materialID, userID)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !roles.Can(role, roles.Editor) {
+		http.Error(res, "Forbidden", http.StatusForbidden)
+		return
+	}
+
 	material.Id = materialID
-	material.UserId = userID
 	err = ctx.Repos.UpdateMaterial(material)
 	if err != nil {
 		http.Error(res, err.Error(), http.StatusInternalServerError)
@@ -0,0 +1,81 @@
+package importer
+
+import (
+	"context"
+	"net/url"
+)
+
+// TypeCourse is the material type course lessons and homework are imported as.
+const TypeCourse = "курс"
+
+// MaxCourseMaterials caps how many materials a course import creates, lessons and homework together.
+const MaxCourseMaterials = 200
+
+// Course is the syllabus of an online course.
+type Course struct {
+	Title       string
+	Description string
+	Link        string
+	Lessons     []Lesson
+}
+
+// Lesson is a course lesson; Homework is set when it has graded assignments.
+type Lesson struct {
+	Title    string
+	Section  string
+	Link     string
+	Homework bool
+}
+
+// Items lists a material per lesson, each followed by one for its homework if it has any, so
+// both earn the xp of a course unit. The list stops at MaxCourseMaterials.
+func (c Course) Items() []Item {
+	items := []Item{}
+	for _, lesson := range c.Lessons {
+		if len(items) >= MaxCourseMaterials {
+			break
+		}
+		items = append(items, Item{
+			Name:        lesson.Title,
+			Description: lesson.Section,
+			Link:        lesson.Link,
+			Type:        TypeCourse,
+			Quantity:    1,
+		})
+		if lesson.Homework && len(items) < MaxCourseMaterials {
+			items = append(items, Item{
+				Name:        "Homework: " + lesson.Title,
+				Description: lesson.Section,
+				Link:        lesson.Link,
+				Type:        TypeCourse,
+				Quantity:    1,
+			})
+		}
+	}
+	return items
+}
+
+// CourseProvider reads course syllabi from one learning platform.
+type CourseProvider interface {
+	// Match reports whether the provider handles link.
+	Match(link *url.URL) bool
+	// Course returns the course at link with its lessons in order.
+	Course(ctx context.Context, link *url.URL) (Course, error)
+}
+
+// CourseProviders picks the first provider matching a link.
+type CourseProviders []CourseProvider
+
+func (providers CourseProviders) Fetch(ctx context.Context, rawURL string) (Course, error) {
+	link, err := parseLink(rawURL)
+	if err != nil {
+		return Course{}, err
+	}
+
+	for _, provider := range providers {
+		if provider.Match(link) {
+			return provider.Course(ctx, link)
+		}
+	}
+	return Course{}, ErrUnsupported
+}
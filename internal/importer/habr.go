@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	habrReadingTimeClass = "tm-article-reading-time__label"
+	habrAuthorClass      = "tm-user-info__username"
+)
+
+var (
+	habrArticlePath     = regexp.MustCompile(`^/(?:[a-z]{2}/)?(?:(?:companies|company)/[^/]+/)?(?:articles|post|news|blog)/\d+/?$`)
+	habrReadingTimeText = regexp.MustCompile(`(\d+)\s*(?:мин|min)`)
+)
+
+// Habr imports Habr articles, taking the author and reading time from the article header.
+type Habr struct {
+	page *WebPage
+}
+
+func NewHabr(page *WebPage) *Habr {
+	return &Habr{page: page}
+}
+
+func (h *Habr) Match(link *url.URL) bool {
+	host := strings.TrimPrefix(strings.ToLower(link.Hostname()), "www.")
+	return (host == "habr.com" || host == "m.habr.com") && habrArticlePath.MatchString(link.Path)
+}
+
+func (h *Habr) Fetch(ctx context.Context, link *url.URL) ([]Item, error) {
+	doc, final, err := h.page.fetch(ctx, link, habrReadingTimeClass, habrAuthorClass)
+	if err != nil {
+		return nil, err
+	}
+
+	item := pageItem(doc, final)
+	item.Name = strings.TrimSuffix(item.Name, " / Хабр")
+	if author := doc.classText[habrAuthorClass]; author != "" {
+		item.Author = author
+	}
+
+	item.ReadingTime = readingTime(doc.articleWords, doc.bodyWords)
+	if match := habrReadingTimeText.FindStringSubmatch(doc.classText[habrReadingTimeClass]); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		item.ReadingTime = time.Duration(minutes) * time.Minute
+	}
+	return []Item{item}, nil
+}
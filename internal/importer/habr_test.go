@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const habrPage = `<html><head>
+<title>Как устроены горутины / Хабр</title>
+<meta property="og:title" content="Как устроены горутины">
+<meta property="og:description" content="Разбираем планировщик Go.">
+</head><body>
+<div class="tm-article-snippet">
+  <span class="tm-user-info tm-article-snippet__author">
+    <a href="/ru/users/gopher/" class="tm-user-info__username"> gopher </a>
+  </span>
+  <span class="tm-article-reading-time"><span class="tm-article-reading-time__label">12 мин</span></span>
+</div>
+<div id="post-content-body">%s</div>
+</body></html>`
+
+func TestHabrMatch(t *testing.T) {
+	habr := NewHabr(NewWebPage(false))
+	for link, want := range map[string]bool{
+		"https://habr.com/ru/articles/812345/":                 true,
+		"https://habr.com/ru/companies/yandex/articles/81234/": true,
+		"https://habr.com/en/post/123/":                        true,
+		"https://habr.com/ru/feed/":                            false,
+		"https://habr.com.example.org/ru/articles/812345/":     false,
+	} {
+		parsed, err := url.Parse(link)
+		require.NoError(t, err)
+		assert.Equal(t, want, habr.Match(parsed), link)
+	}
+}
+
+func TestHabrFetch(t *testing.T) {
+	label := "12 мин"
+	link := serveWebPage(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		page := fmt.Sprintf(habrPage, strings.Repeat("слово ", 1000))
+		fmt.Fprint(w, strings.Replace(page, "12 мин", label, 1))
+	})
+	habr := NewHabr(NewWebPage(true))
+	parsed, err := url.Parse(link + "/ru/articles/1/")
+	require.NoError(t, err)
+
+	items, err := habr.Fetch(context.Background(), parsed)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Item{{
+		Name:        "Как устроены горутины",
+		Description: "Разбираем планировщик Go.",
+		Author:      "gopher",
+		ReadingTime: 12 * time.Minute,
+		Link:        link + "/ru/articles/1/",
+		Type:        TypeArticle,
+		Quantity:    1,
+	}}, items)
+
+	// Without the label the reading time is estimated from the article body.
+	label = ""
+	items, err = habr.Fetch(context.Background(), parsed)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, items[0].ReadingTime)
+}
@@ -0,0 +1,285 @@
+package importer
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// document is what the importers need from an HTML page: its title, meta tags, word counts
+// and the text of the first elements carrying some classes.
+type document struct {
+	title        string
+	meta         map[string]string
+	bodyWords    int
+	articleWords int
+	classText    map[string]string
+}
+
+const (
+	roleTitle = 1 << iota
+	roleBody
+	roleArticle
+	roleSkip
+)
+
+type openElement struct {
+	name    string
+	roles   int
+	classes []string
+}
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// Elements whose contents aren't markup.
+var rawTextElements = map[string]bool{"script": true, "style": true, "textarea": true, "title": true}
+
+// Page chrome left out of the word count.
+var skippedElements = map[string]bool{"nav": true, "header": true, "footer": true, "aside": true, "form": true}
+
+// parseDocument scans markup leniently, without building a tree. Meta tags are keyed by their
+// lowercased name or property, the first one winning.
+func parseDocument(markup string, classes ...string) document {
+	doc := document{meta: map[string]string{}, classText: map[string]string{}}
+	wanted := map[string]bool{}
+	for _, class := range classes {
+		wanted[class] = true
+	}
+
+	var stack []openElement
+	captured := map[string]*strings.Builder{}
+	var title strings.Builder
+
+	roles := func() int {
+		if len(stack) == 0 {
+			return 0
+		}
+		return stack[len(stack)-1].roles
+	}
+	text := func(value string) {
+		value = html.UnescapeString(value)
+		active := roles()
+		if active&roleTitle != 0 {
+			title.WriteString(value)
+			return
+		}
+		if active&roleSkip == 0 {
+			words := len(strings.FieldsFunc(value, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }))
+			if active&roleBody != 0 {
+				doc.bodyWords += words
+			}
+			if active&roleArticle != 0 {
+				doc.articleWords += words
+			}
+		}
+		for _, element := range stack {
+			for _, class := range element.classes {
+				if builder, ok := captured[class]; ok {
+					builder.WriteString(value)
+				}
+			}
+		}
+	}
+	closeElement := func(name string) {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].name != name {
+				continue
+			}
+			for _, element := range stack[i:] {
+				for _, class := range element.classes {
+					if builder, ok := captured[class]; ok {
+						doc.classText[class] = strings.Join(strings.Fields(builder.String()), " ")
+						delete(captured, class)
+						wanted[class] = false
+					}
+				}
+			}
+			stack = stack[:i]
+			return
+		}
+	}
+
+scan:
+	for len(markup) > 0 {
+		start := strings.IndexByte(markup, '<')
+		if start < 0 {
+			text(markup)
+			break
+		}
+		text(markup[:start])
+		markup = markup[start:]
+
+		switch {
+		case strings.HasPrefix(markup, "<!--"):
+			end := strings.Index(markup, "-->")
+			if end < 0 {
+				break scan
+			}
+			markup = markup[end+3:]
+			continue
+		case strings.HasPrefix(markup, "<!"), strings.HasPrefix(markup, "<?"):
+			end := strings.IndexByte(markup, '>')
+			if end < 0 {
+				break scan
+			}
+			markup = markup[end+1:]
+			continue
+		}
+
+		name, attrs, selfClosing, closing, rest, ok := scanTag(markup)
+		if !ok {
+			text("<")
+			markup = markup[1:]
+			continue
+		}
+		markup = rest
+
+		if closing {
+			closeElement(name)
+			continue
+		}
+
+		if name == "meta" {
+			key := strings.ToLower(attrs["property"])
+			if key == "" {
+				key = strings.ToLower(attrs["name"])
+			}
+			if _, seen := doc.meta[key]; key != "" && !seen {
+				doc.meta[key] = strings.TrimSpace(attrs["content"])
+			}
+		}
+		if voidElements[name] || selfClosing {
+			continue
+		}
+
+		element := openElement{name: name, roles: roles()}
+		switch {
+		case name == "title" && doc.title == "" && title.Len() == 0:
+			element.roles |= roleTitle
+		case name == "body":
+			element.roles |= roleBody
+		case name == "article", attrs["itemprop"] == "articleBody", attrs["id"] == "post-content-body":
+			element.roles |= roleArticle
+		case skippedElements[name], rawTextElements[name]:
+			element.roles |= roleSkip
+		}
+		for _, class := range strings.Fields(attrs["class"]) {
+			if wanted[class] && captured[class] == nil {
+				captured[class] = &strings.Builder{}
+				element.classes = append(element.classes, class)
+			}
+		}
+		stack = append(stack, element)
+
+		if rawTextElements[name] {
+			end := indexEndTag(markup, name)
+			if end < 0 {
+				end = len(markup)
+			}
+			if name == "title" {
+				text(markup[:end])
+			}
+			markup = markup[end:]
+		}
+	}
+
+	doc.title = strings.Join(strings.Fields(title.String()), " ")
+	return doc
+}
+
+// scanTag reads the tag at the start of markup, returning the lowercased name, attributes and
+// the markup after the tag.
+func scanTag(markup string) (name string, attrs map[string]string, selfClosing, closing bool, rest string, ok bool) {
+	i := 1
+	if i < len(markup) && markup[i] == '/' {
+		closing = true
+		i++
+	}
+	start := i
+	for i < len(markup) && isNameByte(markup[i]) {
+		i++
+	}
+	if i == start {
+		return "", nil, false, false, markup, false
+	}
+	name = strings.ToLower(markup[start:i])
+
+	attrs = map[string]string{}
+	for {
+		for i < len(markup) && isSpace(markup[i]) {
+			i++
+		}
+		if i >= len(markup) {
+			return name, attrs, false, closing, "", true
+		}
+		switch markup[i] {
+		case '>':
+			return name, attrs, selfClosing, closing, markup[i+1:], true
+		case '/':
+			selfClosing = true
+			i++
+			continue
+		}
+		selfClosing = false
+
+		keyStart := i
+		for i < len(markup) && !isSpace(markup[i]) && markup[i] != '=' && markup[i] != '>' && markup[i] != '/' {
+			i++
+		}
+		key := strings.ToLower(markup[keyStart:i])
+		for i < len(markup) && isSpace(markup[i]) {
+			i++
+		}
+
+		value := ""
+		if i < len(markup) && markup[i] == '=' {
+			i++
+			for i < len(markup) && isSpace(markup[i]) {
+				i++
+			}
+			if i < len(markup) && (markup[i] == '"' || markup[i] == '\'') {
+				quote := markup[i]
+				end := strings.IndexByte(markup[i+1:], quote)
+				if end < 0 {
+					return name, attrs, false, closing, "", true
+				}
+				value = markup[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				valueStart := i
+				for i < len(markup) && !isSpace(markup[i]) && markup[i] != '>' {
+					i++
+				}
+				value = markup[valueStart:i]
+			}
+		}
+		if _, seen := attrs[key]; key != "" && !seen {
+			attrs[key] = html.UnescapeString(value)
+		}
+	}
+}
+
+func isNameByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '-' || b == ':'
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
+
+// indexEndTag finds the end tag of name in s, ignoring the case of the name.
+func indexEndTag(s, name string) int {
+	for i := 0; ; i += 2 {
+		j := strings.Index(s[i:], "</")
+		if j < 0 {
+			return -1
+		}
+		i += j
+		if len(s)-i-2 >= len(name) && strings.EqualFold(s[i+2:i+2+len(name)], name) {
+			return i
+		}
+	}
+}
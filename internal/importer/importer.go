@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported link")
+	ErrNotFound    = errors.New("nothing found at link")
+)
+
+// Item is a material found at an external source. Quantity is measured in the units of its
+// type, e.g. hours for a video.
+type Item struct {
+	Name        string
+	Description string
+	Author      string
+	ReadingTime time.Duration
+	Link        string
+	Type        string
+	Quantity    float64
+}
+
+// Importer turns links of one external source into materials.
+type Importer interface {
+	// Match reports whether the importer handles link.
+	Match(link *url.URL) bool
+	// Fetch returns the items found at link in their original order.
+	Fetch(ctx context.Context, link *url.URL) ([]Item, error)
+}
+
+// Importers picks the first importer matching a link.
+type Importers []Importer
+
+func (importers Importers) Fetch(ctx context.Context, rawURL string) ([]Item, error) {
+	link, err := parseLink(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, importer := range importers {
+		if importer.Match(link) {
+			return importer.Fetch(ctx, link)
+		}
+	}
+	return nil, ErrUnsupported
+}
+
+func parseLink(rawURL string) (*url.URL, error) {
+	link, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+		return nil, ErrUnsupported
+	}
+	return link, nil
+}
+
+// Materials converts items into new materials, each worth its quantity times the xp of its
+// type, matched by name, and at least 1 XP.
+func Materials(items []Item, types []models.TypeMaterial) ([]models.NewMaterial, error) {
+	materials := make([]models.NewMaterial, 0, len(items))
+	for _, item := range items {
+		typeMaterial, ok := findType(item.Type, types)
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q", item.Type)
+		}
+
+		materials = append(materials, models.NewMaterial{
+			Name:        item.Name,
+			Description: description(item),
+			TypeId:      typeMaterial.Id,
+			Xp:          max(1, int(math.Round(item.Quantity*float64(typeMaterial.Xp)))),
+			Link:        item.Link,
+			Tags:        []string{},
+		})
+	}
+	return materials, nil
+}
+
+// description appends the author and reading time, which materials have no fields for.
+func description(item Item) string {
+	var details []string
+	if item.Author != "" {
+		details = append(details, "Author: "+item.Author)
+	}
+	if item.ReadingTime > 0 {
+		details = append(details, fmt.Sprintf("Reading time: %d min", int(item.ReadingTime.Minutes())))
+	}
+	if len(details) == 0 {
+		return item.Description
+	}
+	return strings.TrimSpace(item.Description + "\n\n" + strings.Join(details, "\n"))
+}
+
+func findType(name string, types []models.TypeMaterial) (models.TypeMaterial, bool) {
+	for _, typeMaterial := range types {
+		if strings.EqualFold(typeMaterial.Name, name) {
+			return typeMaterial, true
+		}
+	}
+	return models.TypeMaterial{}, false
+}
@@ -0,0 +1,27 @@
+package importer
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMaterials(t *testing.T) {
+	types := []models.TypeMaterial{{Id: "video", Name: "Видеоролик", Xp: 10}}
+
+	materials, err := Materials([]Item{
+		{Name: "Talk", Link: "https://example.com/talk", Type: TypeVideo, Quantity: 1.5},
+		{Name: "Short", Description: "Intro", Author: "Rob", ReadingTime: 7 * time.Minute, Type: TypeVideo, Quantity: 0.01},
+	}, types)
+
+	require.NoError(t, err)
+	require.Len(t, materials, 2)
+	assert.Equal(t, models.NewMaterial{Name: "Talk", TypeId: "video", Xp: 15, Link: "https://example.com/talk", Tags: []string{}}, materials[0])
+	assert.Equal(t, 1, materials[1].Xp)
+	assert.Equal(t, "Intro\n\nAuthor: Rob\nReading time: 7 min", materials[1].Description)
+
+	_, err = Materials([]Item{{Name: "Book", Type: "книга"}}, types)
+	assert.EqualError(t, err, `unknown type "книга"`)
+}
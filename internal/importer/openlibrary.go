@@ -0,0 +1,187 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TypeBook is the material type books are added as.
+const TypeBook = "книга"
+
+var ErrInvalidISBN = errors.New("invalid ISBN")
+
+// OpenLibrary looks books up by ISBN through the Open Library API.
+type OpenLibrary struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewOpenLibrary(baseURL string) *OpenLibrary {
+	return &OpenLibrary{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type openLibraryEdition struct {
+	Title         string           `json:"title"`
+	Subtitle      string           `json:"subtitle"`
+	NumberOfPages int              `json:"number_of_pages"`
+	Description   openLibraryText  `json:"description"`
+	Authors       []openLibraryKey `json:"authors"`
+	Works         []openLibraryKey `json:"works"`
+}
+
+type openLibraryKey struct {
+	Key string `json:"key"`
+}
+
+type openLibraryAuthor struct {
+	Name string `json:"name"`
+}
+
+type openLibraryWork struct {
+	Description openLibraryText `json:"description"`
+}
+
+// openLibraryText is either a plain string or a {"type": ..., "value": ...} object.
+type openLibraryText string
+
+func (t *openLibraryText) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*t = openLibraryText(value)
+		return nil
+	}
+	var typed struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	*t = openLibraryText(typed.Value)
+	return nil
+}
+
+// Book fetches the edition with the ISBN, then its authors' names and, if the edition has no
+// description, its work's.
+func (o *OpenLibrary) Book(ctx context.Context, isbn string) (models.Book, error) {
+	isbn, err := NormalizeISBN(isbn)
+	if err != nil {
+		return models.Book{}, err
+	}
+
+	var edition openLibraryEdition
+	if err := o.get(ctx, "/isbn/"+isbn+".json", &edition); err != nil {
+		return models.Book{}, err
+	}
+
+	book := models.Book{
+		ISBN:        isbn,
+		Title:       strings.TrimSpace(edition.Title),
+		Authors:     []string{},
+		Description: strings.TrimSpace(string(edition.Description)),
+		Pages:       edition.NumberOfPages,
+		Link:        o.baseURL + "/isbn/" + isbn,
+	}
+	if subtitle := strings.TrimSpace(edition.Subtitle); subtitle != "" {
+		book.Title += ": " + subtitle
+	}
+
+	for _, author := range edition.Authors {
+		var record openLibraryAuthor
+		if err := o.get(ctx, author.Key+".json", &record); err != nil {
+			return models.Book{}, err
+		}
+		if name := strings.TrimSpace(record.Name); name != "" {
+			book.Authors = append(book.Authors, name)
+		}
+	}
+
+	if book.Description == "" && len(edition.Works) > 0 {
+		var work openLibraryWork
+		if err := o.get(ctx, edition.Works[0].Key+".json", &work); err != nil && !errors.Is(err, ErrNotFound) {
+			return models.Book{}, err
+		}
+		book.Description = strings.TrimSpace(string(work.Description))
+	}
+
+	return book, nil
+}
+
+func (o *OpenLibrary) get(ctx context.Context, path string, target any) error {
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("open library: invalid key %q", path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("open library: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("open library: unexpected status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("open library: %w", err)
+	}
+	return nil
+}
+
+// NormalizeISBN strips hyphens and spaces and checks the ISBN-10 or ISBN-13 check digit.
+func NormalizeISBN(value string) (string, error) {
+	isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(value)))
+
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			digit := int(r - '0')
+			switch {
+			case r == 'X' && i == 9:
+				digit = 10
+			case r < '0' || r > '9':
+				return "", ErrInvalidISBN
+			}
+			sum += (10 - i) * digit
+		}
+		if sum%11 != 0 {
+			return "", ErrInvalidISBN
+		}
+	case 13:
+		sum := 0
+		for i, r := range isbn {
+			if r < '0' || r > '9' {
+				return "", ErrInvalidISBN
+			}
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += weight * int(r-'0')
+		}
+		if sum%10 != 0 {
+			return "", ErrInvalidISBN
+		}
+	default:
+		return "", ErrInvalidISBN
+	}
+	return isbn, nil
+}
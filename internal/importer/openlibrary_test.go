@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newFakeOpenLibrary(t *testing.T) *httptest.Server {
+	responses := map[string]string{
+		"/isbn/9780134190440.json": `{"title":"The Go Programming Language","number_of_pages":380,"authors":[{"key":"/authors/OL1A"},{"key":"/authors/OL2A"}],"works":[{"key":"/works/OL1W"}]}`,
+		"/isbn/0131103628.json":    `{"title":"The C Programming Language","subtitle":"ANSI C","description":{"type":"/type/text","value":"K&R"},"authors":[]}`,
+		"/authors/OL1A.json":       `{"name":"Alan Donovan"}`,
+		"/authors/OL2A.json":       `{"name":"Brian Kernighan"}`,
+		"/works/OL1W.json":         `{"description":"The authoritative resource."}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenLibrary_Book(t *testing.T) {
+	server := newFakeOpenLibrary(t)
+	library := NewOpenLibrary(server.URL)
+
+	book, err := library.Book(context.Background(), "978-0-13-419044-0")
+	require.NoError(t, err)
+	assert.Equal(t, "9780134190440", book.ISBN)
+	assert.Equal(t, "The Go Programming Language", book.Title)
+	assert.Equal(t, []string{"Alan Donovan", "Brian Kernighan"}, book.Authors)
+	assert.Equal(t, "The authoritative resource.", book.Description)
+	assert.Equal(t, 380, book.Pages)
+	assert.Equal(t, server.URL+"/isbn/9780134190440", book.Link)
+
+	book, err = library.Book(context.Background(), "0-13-110362-8")
+	require.NoError(t, err)
+	assert.Equal(t, "The C Programming Language: ANSI C", book.Title)
+	assert.Equal(t, "K&R", book.Description)
+	assert.Empty(t, book.Authors)
+
+	_, err = library.Book(context.Background(), "9780306406157")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = library.Book(context.Background(), "9780134190441")
+	assert.ErrorIs(t, err, ErrInvalidISBN)
+}
+
+func TestNormalizeISBN(t *testing.T) {
+	valid := map[string]string{
+		"978-0-13-419044-0": "9780134190440",
+		"0 13 110362 8":     "0131103628",
+		"080442957x":        "080442957X",
+	}
+	for value, want := range valid {
+		isbn, err := NormalizeISBN(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, isbn)
+	}
+
+	for _, value := range []string{"", "12345", "0131103627", "97801341904X0", "X131103628"} {
+		_, err := NormalizeISBN(value)
+		assert.ErrorIs(t, err, ErrInvalidISBN, value)
+	}
+}
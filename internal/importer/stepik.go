@@ -0,0 +1,215 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ids requested per call to the Stepik API.
+const stepikPageSize = 50
+
+// Step kinds that only present material; any other step is a graded assignment.
+var stepikTheoryBlocks = map[string]bool{"text": true, "video": true}
+
+var stepikCoursePath = regexp.MustCompile(`^/course/(\d+)(?:/.*)?$`)
+
+// Stepik reads course syllabi through the public Stepik API.
+type Stepik struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewStepik(baseURL string) *Stepik {
+	return &Stepik{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *Stepik) Match(link *url.URL) bool {
+	host := strings.TrimPrefix(strings.ToLower(link.Hostname()), "www.")
+	return host == "stepik.org" && stepikCoursePath.MatchString(link.Path)
+}
+
+type stepikCourse struct {
+	Id       int    `json:"id"`
+	Title    string `json:"title"`
+	Summary  string `json:"summary"`
+	Sections []int  `json:"sections"`
+}
+
+type stepikSection struct {
+	Id    int    `json:"id"`
+	Title string `json:"title"`
+	Units []int  `json:"units"`
+}
+
+type stepikUnit struct {
+	Id     int `json:"id"`
+	Lesson int `json:"lesson"`
+}
+
+type stepikLesson struct {
+	Id    int    `json:"id"`
+	Title string `json:"title"`
+	Steps []int  `json:"steps"`
+}
+
+type stepikStep struct {
+	Id    int `json:"id"`
+	Block struct {
+		Name string `json:"name"`
+	} `json:"block"`
+}
+
+func (c stepikCourse) key() int  { return c.Id }
+func (s stepikSection) key() int { return s.Id }
+func (u stepikUnit) key() int    { return u.Id }
+func (l stepikLesson) key() int  { return l.Id }
+func (s stepikStep) key() int    { return s.Id }
+
+// Course walks course → sections → units → lessons → steps, a request per batch of ids.
+func (s *Stepik) Course(ctx context.Context, link *url.URL) (Course, error) {
+	match := stepikCoursePath.FindStringSubmatch(link.Path)
+	if match == nil {
+		return Course{}, ErrUnsupported
+	}
+	courseID, err := strconv.Atoi(match[1])
+	if err != nil {
+		return Course{}, ErrUnsupported
+	}
+
+	courses, err := stepikObjects[stepikCourse](ctx, s, "courses", []int{courseID})
+	if err != nil {
+		return Course{}, err
+	}
+	course, ok := courses[courseID]
+	if !ok {
+		return Course{}, ErrNotFound
+	}
+
+	sections, err := stepikObjects[stepikSection](ctx, s, "sections", course.Sections)
+	if err != nil {
+		return Course{}, err
+	}
+
+	var unitIDs []int
+	for _, id := range course.Sections {
+		unitIDs = append(unitIDs, sections[id].Units...)
+	}
+	units, err := stepikObjects[stepikUnit](ctx, s, "units", unitIDs[:min(len(unitIDs), MaxCourseMaterials)])
+	if err != nil {
+		return Course{}, err
+	}
+
+	// Lessons in syllabus order, with the section each belongs to.
+	var lessonIDs []int
+	sectionOf := map[int]string{}
+	for _, id := range course.Sections {
+		for _, unitID := range sections[id].Units {
+			if unit, ok := units[unitID]; ok {
+				lessonIDs = append(lessonIDs, unit.Lesson)
+				sectionOf[unit.Lesson] = sections[id].Title
+			}
+		}
+	}
+
+	lessons, err := stepikObjects[stepikLesson](ctx, s, "lessons", lessonIDs)
+	if err != nil {
+		return Course{}, err
+	}
+	var stepIDs []int
+	for _, id := range lessonIDs {
+		stepIDs = append(stepIDs, lessons[id].Steps...)
+	}
+	steps, err := stepikObjects[stepikStep](ctx, s, "steps", stepIDs)
+	if err != nil {
+		return Course{}, err
+	}
+
+	result := Course{
+		Title:       course.Title,
+		Description: course.Summary,
+		Link:        fmt.Sprintf("%s://%s/course/%d", link.Scheme, link.Host, course.Id),
+		Lessons:     []Lesson{},
+	}
+	for _, id := range lessonIDs {
+		lesson, ok := lessons[id]
+		if !ok {
+			continue
+		}
+		homework := false
+		for _, stepID := range lesson.Steps {
+			if step, ok := steps[stepID]; ok && !stepikTheoryBlocks[step.Block.Name] {
+				homework = true
+				break
+			}
+		}
+		result.Lessons = append(result.Lessons, Lesson{
+			Title:    lesson.Title,
+			Section:  sectionOf[id],
+			Link:     fmt.Sprintf("%s://%s/lesson/%d", link.Scheme, link.Host, lesson.Id),
+			Homework: homework,
+		})
+	}
+
+	if len(result.Lessons) == 0 {
+		return Course{}, ErrNotFound
+	}
+	return result, nil
+}
+
+// stepikObjects fetches objects of a resource by id in batches. Objects the API doesn't
+// return, e.g. private ones, are missing from the result.
+func stepikObjects[T interface{ key() int }](ctx context.Context, s *Stepik, resource string, ids []int) (map[int]T, error) {
+	objects := make(map[int]T, len(ids))
+	for start := 0; start < len(ids); start += stepikPageSize {
+		query := url.Values{}
+		for _, id := range ids[start:min(start+stepikPageSize, len(ids))] {
+			query.Add("ids[]", strconv.Itoa(id))
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/"+resource+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("stepik %s: %w", resource, err)
+		}
+
+		var response map[string]json.RawMessage
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			err = ErrNotFound
+		case resp.StatusCode != http.StatusOK:
+			err = fmt.Errorf("stepik %s: unexpected status %d", resource, resp.StatusCode)
+		default:
+			if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				err = fmt.Errorf("stepik %s: %w", resource, err)
+			}
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		var batch []T
+		if err := json.Unmarshal(response[resource], &batch); err != nil && response[resource] != nil {
+			return nil, fmt.Errorf("stepik %s: %w", resource, err)
+		}
+		for _, object := range batch {
+			objects[object.key()] = object
+		}
+	}
+	return objects, nil
+}
@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// stepikFixtures holds course 7: two sections with three lessons, the second of which has a
+// code challenge.
+var stepikFixtures = map[string]map[int]any{
+	"courses": {7: map[string]any{"id": 7, "title": "Go basics", "summary": "Learn Go", "sections": []int{1, 2}}},
+	"sections": {
+		1: map[string]any{"id": 1, "title": "Intro", "units": []int{11, 12}},
+		2: map[string]any{"id": 2, "title": "Types", "units": []int{21}},
+	},
+	"units": {
+		11: map[string]any{"id": 11, "lesson": 101},
+		12: map[string]any{"id": 12, "lesson": 102},
+		21: map[string]any{"id": 21, "lesson": 201},
+	},
+	"lessons": {
+		101: map[string]any{"id": 101, "title": "Hello", "steps": []int{1001}},
+		102: map[string]any{"id": 102, "title": "Loops", "steps": []int{1002, 1003}},
+		201: map[string]any{"id": 201, "title": "Structs", "steps": []int{2001}},
+	},
+	"steps": {
+		1001: map[string]any{"id": 1001, "block": map[string]any{"name": "text"}},
+		1002: map[string]any{"id": 1002, "block": map[string]any{"name": "video"}},
+		1003: map[string]any{"id": 1003, "block": map[string]any{"name": "code"}},
+		2001: map[string]any{"id": 2001, "block": map[string]any{"name": "text"}},
+	},
+}
+
+func newFakeStepik(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := r.URL.Path[1:]
+		fixtures, ok := stepikFixtures[resource]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		objects := []any{}
+		for _, value := range r.URL.Query()["ids[]"] {
+			id, err := strconv.Atoi(value)
+			require.NoError(t, err)
+			if object, ok := fixtures[id]; ok {
+				objects = append(objects, object)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{"page": 1}, resource: objects})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestStepikMatch(t *testing.T) {
+	stepik := NewStepik("http://localhost")
+	for link, want := range map[string]bool{
+		"https://stepik.org/course/7/promo":    true,
+		"https://stepik.org/course/7":          true,
+		"https://www.stepik.org/course/7/info": true,
+		"https://stepik.org/lesson/101":        false,
+		"https://example.com/course/7":         false,
+	} {
+		parsed, err := url.Parse(link)
+		require.NoError(t, err)
+		assert.Equal(t, want, stepik.Match(parsed), link)
+	}
+}
+
+func TestStepikCourse(t *testing.T) {
+	server := newFakeStepik(t)
+	providers := CourseProviders{NewStepik(server.URL)}
+
+	course, err := providers.Fetch(context.Background(), "https://stepik.org/course/7/promo")
+
+	require.NoError(t, err)
+	assert.Equal(t, Course{
+		Title:       "Go basics",
+		Description: "Learn Go",
+		Link:        "https://stepik.org/course/7",
+		Lessons: []Lesson{
+			{Title: "Hello", Section: "Intro", Link: "https://stepik.org/lesson/101"},
+			{Title: "Loops", Section: "Intro", Link: "https://stepik.org/lesson/102", Homework: true},
+			{Title: "Structs", Section: "Types", Link: "https://stepik.org/lesson/201"},
+		},
+	}, course)
+
+	names := []string{}
+	for _, item := range course.Items() {
+		assert.Equal(t, TypeCourse, item.Type)
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"Hello", "Loops", "Homework: Loops", "Structs"}, names)
+}
+
+func TestCourseItems_Max(t *testing.T) {
+	course := Course{}
+	for i := 0; i < MaxCourseMaterials; i++ {
+		course.Lessons = append(course.Lessons, Lesson{Title: "Lesson", Homework: true})
+	}
+
+	items := course.Items()
+
+	assert.Len(t, items, MaxCourseMaterials)
+	assert.Equal(t, "Homework: Lesson", items[len(items)-1].Name)
+}
+
+func TestStepikCourseErrors(t *testing.T) {
+	server := newFakeStepik(t)
+
+	_, err := CourseProviders{NewStepik(server.URL)}.Fetch(context.Background(), "https://stepik.org/course/8")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = CourseProviders{NewStepik(server.URL)}.Fetch(context.Background(), "https://example.com/course/7")
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	_, err = CourseProviders{NewStepik(server.URL + "/broken")}.Fetch(context.Background(), "https://stepik.org/course/7")
+	assert.EqualError(t, err, "stepik courses: unexpected status 500")
+}
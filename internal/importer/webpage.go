@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// TypeArticle is the material type web pages are imported as.
+const TypeArticle = "статья"
+
+// MaxPageSize caps how much of a page is downloaded.
+const MaxPageSize = 2 << 20
+
+const (
+	pageTimeout  = 10 * time.Second
+	maxRedirects = 5
+	wordsPerMin  = 200
+)
+
+var (
+	ErrForbiddenAddress = errors.New("address not allowed")
+	ErrTooLarge         = errors.New("page too large")
+)
+
+// Ranges that are not on the public internet, besides what netip.Addr reports as private,
+// loopback, link-local or multicast.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// WebPage imports any HTML page as an article, reading its OpenGraph and meta tags. It matches
+// every link, so it goes last among the importers.
+type WebPage struct {
+	client *http.Client
+}
+
+// NewWebPage returns an importer fetching pages with NewClient.
+func NewWebPage(allowPrivate bool) *WebPage {
+	return &WebPage{client: NewClient(allowPrivate)}
+}
+
+// NewClient returns an HTTP client for user-supplied links. It refuses to connect to private
+// and reserved addresses, including after redirects, unless allowPrivate is set.
+func NewClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivate {
+		// Checking the address being dialled, after resolution, also covers DNS rebinding.
+		dialer.Control = func(network, address string, conn syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !publicAddr(addrPort.Addr()) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: pageTimeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: pageTimeout,
+			// Clients are mostly built per request, so their connections aren't kept for reuse.
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("too many redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrUnsupported
+			}
+			return nil
+		},
+	}
+}
+
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *WebPage) Match(link *url.URL) bool {
+	return true
+}
+
+func (w *WebPage) Fetch(ctx context.Context, link *url.URL) ([]Item, error) {
+	doc, final, err := w.fetch(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	item := pageItem(doc, final)
+	item.ReadingTime = readingTime(doc.articleWords, doc.bodyWords)
+	return []Item{item}, nil
+}
+
+// fetch downloads and parses the page, returning the URL it ended up at after redirects.
+func (w *WebPage) fetch(ctx context.Context, link *url.URL, classes ...string) (document, *url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return document{}, nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "SkillBuilder/1.0 (+link import)")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrForbiddenAddress):
+			return document{}, nil, ErrForbiddenAddress
+		case errors.Is(err, ErrUnsupported):
+			return document{}, nil, ErrUnsupported
+		}
+		return document{}, nil, fmt.Errorf("fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return document{}, nil, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return document{}, nil, fmt.Errorf("fetch page: unexpected status %d", resp.StatusCode)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return document{}, nil, ErrUnsupported
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxPageSize+1))
+	if err != nil {
+		return document{}, nil, fmt.Errorf("fetch page: %w", err)
+	}
+	if len(body) > MaxPageSize {
+		return document{}, nil, ErrTooLarge
+	}
+
+	return parseDocument(string(body), classes...), resp.Request.URL, nil
+}
+
+// pageItem reads the article's name, description and author from OpenGraph, Twitter card and
+// plain HTML meta tags, in that order.
+func pageItem(doc document, link *url.URL) Item {
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := doc.meta[key]; value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	item := Item{
+		Name:        first("og:title", "twitter:title"),
+		Description: first("og:description", "description", "twitter:description"),
+		Author:      first("author", "article:author", "twitter:creator"),
+		Link:        link.String(),
+		Type:        TypeArticle,
+		Quantity:    1,
+	}
+	if item.Name == "" {
+		item.Name = doc.title
+	}
+	if item.Name == "" {
+		item.Name = link.Host + link.Path
+	}
+	// article:author is often a profile link rather than a name.
+	if strings.HasPrefix(item.Author, "http://") || strings.HasPrefix(item.Author, "https://") {
+		item.Author = ""
+	}
+	return item
+}
+
+// readingTime estimates reading time from the article's words, or the whole page's when no
+// article element was found.
+func readingTime(articleWords, bodyWords int) time.Duration {
+	words := articleWords
+	if words == 0 {
+		words = bodyWords
+	}
+	if words == 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(float64(words)/wordsPerMin)) * time.Minute
+}
@@ -0,0 +1,165 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+const articlePage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Fallback &amp; title</title>
+  <meta property="og:title" content="Understanding Go &quot;interfaces&quot;">
+  <meta name="description" content="A look at interface values.">
+  <meta name="author" content="Rob">
+  <script>var words = "<article> these are not words </article>";</script>
+</head>
+<body>
+  <nav>Home About Blog</nav>
+  <article>%s</article>
+  <footer>Copyright</footer>
+</body>
+</html>`
+
+func serveWebPage(t *testing.T, handler http.HandlerFunc) string {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestParseDocument(t *testing.T) {
+	doc := parseDocument(`<HTML><Title>  Go
+		blog </Title><META NAME="Description" content=first><meta name=description content=second>
+		<body><p class="lead note">Hello, <b>world</b>!</p><!-- <p>hidden words</p> --><ARTICLE>One two<br/>three</ARTICLE>
+		<style>p { color: red }</style></body></HTML>`, "note")
+
+	assert.Equal(t, "Go blog", doc.title)
+	assert.Equal(t, "first", doc.meta["description"])
+	assert.Equal(t, 5, doc.bodyWords)
+	assert.Equal(t, 3, doc.articleWords)
+	assert.Equal(t, "Hello, world!", doc.classText["note"])
+}
+
+func TestWebPageFetch(t *testing.T) {
+	link := serveWebPage(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/article", http.StatusMovedPermanently)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, articlePage, strings.Repeat("word ", 450))
+	})
+
+	items, err := Importers{NewWebPage(true)}.Fetch(context.Background(), link+"/old")
+
+	require.NoError(t, err)
+	assert.Equal(t, []Item{{
+		Name:        `Understanding Go "interfaces"`,
+		Description: "A look at interface values.",
+		Author:      "Rob",
+		ReadingTime: 3 * time.Minute,
+		Link:        link + "/article",
+		Type:        TypeArticle,
+		Quantity:    1,
+	}}, items)
+}
+
+func TestWebPageFetchFallbacks(t *testing.T) {
+	link := serveWebPage(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<title>Plain page</title><body><p>Just a few words here</p></body>`)
+	})
+
+	items, err := Importers{NewWebPage(true)}.Fetch(context.Background(), link)
+
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Plain page", items[0].Name)
+	assert.Equal(t, time.Minute, items[0].ReadingTime)
+}
+
+func TestWebPageFetchErrors(t *testing.T) {
+	link := serveWebPage(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte{0x89, 'P', 'N', 'G'})
+		case "/huge":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(strings.Repeat("a", MaxPageSize+1)))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	page := NewWebPage(true)
+
+	_, err := Importers{page}.Fetch(context.Background(), link+"/missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = Importers{page}.Fetch(context.Background(), link+"/image")
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	_, err = Importers{page}.Fetch(context.Background(), link+"/huge")
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	_, err = Importers{page}.Fetch(context.Background(), link+"/loop")
+	assert.ErrorContains(t, err, "too many redirects")
+
+	_, err = Importers{page}.Fetch(context.Background(), link+"/broken")
+	assert.EqualError(t, err, "fetch page: unexpected status 500")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = Importers{page}.Fetch(ctx, link+"/slow")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWebPageRefusesPrivateAddresses(t *testing.T) {
+	requested := false
+	link := serveWebPage(t, func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	})
+	page := NewWebPage(false)
+
+	_, err := Importers{page}.Fetch(context.Background(), link)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+
+	// Host names are checked once resolved.
+	_, err = Importers{page}.Fetch(context.Background(), "http://localhost:1/")
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.False(t, requested)
+}
+
+func TestPublicAddr(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"8.8.8.8":          true,
+		"2a00:1450::1":     true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fd00::1":          false,
+		"fe80::1":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		assert.Equal(t, want, publicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
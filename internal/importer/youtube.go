@@ -0,0 +1,248 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TypeVideo is the material type YouTube videos are imported as.
+const TypeVideo = "видеоролик"
+
+// MaxPlaylistItems caps how many videos a playlist import creates.
+const MaxPlaylistItems = 200
+
+// The Data API accepts at most 50 ids or results per request.
+const youtubePageSize = 50
+
+// YouTube imports videos and playlists through the YouTube Data API v3.
+type YouTube struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewYouTube(baseURL, apiKey string) *YouTube {
+	return &YouTube{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (y *YouTube) Match(link *url.URL) bool {
+	videoID, playlistID := youtubeIDs(link)
+	return videoID != "" || playlistID != ""
+}
+
+func (y *YouTube) Fetch(ctx context.Context, link *url.URL) ([]Item, error) {
+	videoID, playlistID := youtubeIDs(link)
+	if videoID != "" {
+		return y.videos(ctx, []string{videoID})
+	}
+	if playlistID == "" {
+		return nil, ErrUnsupported
+	}
+
+	ids, err := y.playlistVideoIDs(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+	return y.videos(ctx, ids)
+}
+
+// youtubeIDs extracts a video or playlist id from watch, short, embed and playlist links.
+func youtubeIDs(link *url.URL) (videoID, playlistID string) {
+	host := strings.TrimPrefix(strings.ToLower(link.Hostname()), "www.")
+	path := strings.Trim(link.Path, "/")
+
+	switch host {
+	case "youtu.be":
+		return validYouTubeID(path), ""
+	case "youtube.com", "m.youtube.com", "music.youtube.com":
+	default:
+		return "", ""
+	}
+
+	switch {
+	case path == "watch":
+		return validYouTubeID(link.Query().Get("v")), ""
+	case path == "playlist":
+		return "", validYouTubeID(link.Query().Get("list"))
+	}
+	for _, prefix := range []string{"shorts/", "embed/", "live/"} {
+		if id, ok := strings.CutPrefix(path, prefix); ok {
+			return validYouTubeID(id), ""
+		}
+	}
+	return "", ""
+}
+
+var youtubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func validYouTubeID(id string) string {
+	if !youtubeIDPattern.MatchString(id) {
+		return ""
+	}
+	return id
+}
+
+type youtubeVideos struct {
+	Items []struct {
+		Id      string `json:"id"`
+		Snippet struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"snippet"`
+		ContentDetails struct {
+			Duration string `json:"duration"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+type youtubePlaylistItems struct {
+	NextPageToken string `json:"nextPageToken"`
+	Items         []struct {
+		ContentDetails struct {
+			VideoId string `json:"videoId"`
+		} `json:"contentDetails"`
+	} `json:"items"`
+}
+
+type youtubeError struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// videos looks the ids up in batches, skipping videos that are private or deleted.
+func (y *YouTube) videos(ctx context.Context, ids []string) ([]Item, error) {
+	items := []Item{}
+	for start := 0; start < len(ids); start += youtubePageSize {
+		batch := ids[start:min(start+youtubePageSize, len(ids))]
+
+		var response youtubeVideos
+		err := y.get(ctx, "videos", url.Values{
+			"part": {"snippet,contentDetails"},
+			"id":   {strings.Join(batch, ",")},
+		}, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, video := range response.Items {
+			duration, err := parseISODuration(video.ContentDetails.Duration)
+			if err != nil {
+				return nil, fmt.Errorf("video %s: %w", video.Id, err)
+			}
+			items = append(items, Item{
+				Name:        video.Snippet.Title,
+				Description: video.Snippet.Description,
+				Link:        "https://www.youtube.com/watch?v=" + video.Id,
+				Type:        TypeVideo,
+				Quantity:    duration.Hours(),
+			})
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, ErrNotFound
+	}
+	return items, nil
+}
+
+func (y *YouTube) playlistVideoIDs(ctx context.Context, playlistID string) ([]string, error) {
+	var ids []string
+	pageToken := ""
+	for len(ids) < MaxPlaylistItems {
+		query := url.Values{
+			"part":       {"contentDetails"},
+			"playlistId": {playlistID},
+			"maxResults": {strconv.Itoa(youtubePageSize)},
+		}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		var response youtubePlaylistItems
+		if err := y.get(ctx, "playlistItems", query, &response); err != nil {
+			return nil, err
+		}
+		for _, item := range response.Items {
+			ids = append(ids, item.ContentDetails.VideoId)
+		}
+
+		if response.NextPageToken == "" {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+
+	if len(ids) == 0 {
+		return nil, ErrNotFound
+	}
+	return ids[:min(len(ids), MaxPlaylistItems)], nil
+}
+
+// get calls a Data API resource. The key goes in a header rather than the query, so it
+// doesn't end up in errors that quote the request URL.
+func (y *YouTube) get(ctx context.Context, resource string, query url.Values, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, y.baseURL+"/"+resource+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Goog-Api-Key", y.apiKey)
+
+	resp, err := y.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("youtube %s: %w", resource, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiError youtubeError
+		if json.NewDecoder(resp.Body).Decode(&apiError) == nil && apiError.Error.Message != "" {
+			return fmt.Errorf("youtube %s: %s", resource, apiError.Error.Message)
+		}
+		return fmt.Errorf("youtube %s: unexpected status %d", resource, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("youtube %s: %w", resource, err)
+	}
+	return nil
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseISODuration parses the ISO 8601 durations the Data API reports, such as PT1H2M3S.
+func parseISODuration(value string) (time.Duration, error) {
+	match := isoDurationPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, errors.New("invalid duration " + strconv.Quote(value))
+	}
+
+	var duration time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if match[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(n) * unit
+	}
+	return duration, nil
+}
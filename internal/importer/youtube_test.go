@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeYouTube serves a playlist of 60 videos, split over two pages, where video59 is private.
+func newFakeYouTube(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Goog-Api-Key"))
+		assert.Empty(t, r.URL.Query().Get("key"))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/videos":
+			var items []string
+			for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+				if id == "video59" || id == "missing" {
+					continue
+				}
+				items = append(items, fmt.Sprintf(`{"id":%q,"snippet":{"title":"Title %s","description":"About %s"},"contentDetails":{"duration":"PT1H30M"}}`, id, id, id))
+			}
+			fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+		case "/playlistItems":
+			if r.URL.Query().Get("playlistId") != "PL1" {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":404,"message":"playlist not found"}}`)
+				return
+			}
+			first, next := 0, `"nextPageToken":"page2",`
+			if r.URL.Query().Get("pageToken") == "page2" {
+				first, next = 50, ""
+			}
+			var items []string
+			for i := first; i < min(first+50, 60); i++ {
+				items = append(items, fmt.Sprintf(`{"contentDetails":{"videoId":"video%d"}}`, i))
+			}
+			fmt.Fprintf(w, `{%s"items":[%s]}`, next, strings.Join(items, ","))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":403,"message":"quota exceeded"}}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestYouTubeMatch(t *testing.T) {
+	youtube := NewYouTube("http://localhost", "")
+	for link, want := range map[string]bool{
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ":     true,
+		"https://youtu.be/dQw4w9WgXcQ":                    true,
+		"https://m.youtube.com/shorts/dQw4w9WgXcQ":        true,
+		"https://www.youtube.com/playlist?list=PL1":       true,
+		"https://www.youtube.com/channel/UC1":             false,
+		"https://www.youtube.com/watch?v=bad/id":          false,
+		"https://example.com/watch?v=dQw4w9WgXcQ":         false,
+		"https://notyoutube.com/watch?v=dQw4w9WgXcQ":      false,
+		"https://www.youtube.com/embed/dQw4w9WgXcQ?rel=0": true,
+	} {
+		parsed, err := url.Parse(link)
+		require.NoError(t, err)
+		assert.Equal(t, want, youtube.Match(parsed), link)
+	}
+}
+
+func TestYouTubeFetchVideo(t *testing.T) {
+	server := newFakeYouTube(t)
+	importers := Importers{NewYouTube(server.URL, "secret")}
+
+	items, err := importers.Fetch(context.Background(), "https://youtu.be/abc")
+
+	require.NoError(t, err)
+	assert.Equal(t, []Item{{
+		Name:        "Title abc",
+		Description: "About abc",
+		Link:        "https://www.youtube.com/watch?v=abc",
+		Type:        TypeVideo,
+		Quantity:    1.5,
+	}}, items)
+}
+
+func TestYouTubeFetchPlaylist(t *testing.T) {
+	server := newFakeYouTube(t)
+	importers := Importers{NewYouTube(server.URL, "secret")}
+
+	items, err := importers.Fetch(context.Background(), "https://www.youtube.com/playlist?list=PL1")
+
+	require.NoError(t, err)
+	require.Len(t, items, 59)
+	assert.Equal(t, "Title video0", items[0].Name)
+	assert.Equal(t, "Title video58", items[58].Name)
+}
+
+func TestYouTubeFetchErrors(t *testing.T) {
+	server := newFakeYouTube(t)
+	importers := Importers{NewYouTube(server.URL, "secret")}
+
+	_, err := importers.Fetch(context.Background(), "https://www.youtube.com/playlist?list=PL2")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = importers.Fetch(context.Background(), "https://youtu.be/missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = importers.Fetch(context.Background(), "https://example.com/video")
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	_, err = Importers{NewYouTube(server.URL+"/forbidden", "secret")}.Fetch(context.Background(), "https://youtu.be/abc")
+	assert.EqualError(t, err, "youtube videos: quota exceeded")
+}
+
+func TestParseISODuration(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"PT1H2M3S": time.Hour + 2*time.Minute + 3*time.Second,
+		"PT45S":    45 * time.Second,
+		"P1DT2H":   26 * time.Hour,
+		"P0D":      0,
+	} {
+		got, err := parseISODuration(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	_, err := parseISODuration("1:02:03")
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// BatchSize is how many due links are loaded at a time.
+const BatchSize = 500
+
+// MaxBackoff caps how long a link that keeps failing waits before its next check.
+const MaxBackoff = 30 * 24 * time.Hour
+
+type Repositories interface {
+	GetLinksToCheck(limit int) ([]models.LinkCheck, error)
+	SaveLinkCheck(check models.LinkCheck, nextCheck time.Time) error
+}
+
+// Checker periodically requests material links and records whether they still work.
+type Checker struct {
+	repos       Repositories
+	client      *http.Client
+	interval    time.Duration
+	concurrency int
+}
+
+func New(repos Repositories, client *http.Client, interval time.Duration, concurrency int) *Checker {
+	return &Checker{repos: repos, client: client, interval: interval, concurrency: max(concurrency, 1)}
+}
+
+// Run checks due links every interval until ctx is cancelled.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, _, err := c.CheckAll(ctx); err != nil {
+				log.Println("link check:", err)
+			}
+		}
+	}
+}
+
+// CheckAll checks every due link, at most concurrency at a time, and returns how many were
+// checked and how many of them are broken.
+func (c *Checker) CheckAll(ctx context.Context) (int, int, error) {
+	checked, broken := 0, 0
+	for {
+		links, err := c.repos.GetLinksToCheck(BatchSize)
+		if err != nil {
+			return checked, broken, err
+		}
+
+		var mu sync.Mutex
+		var saveErr error
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, c.concurrency)
+		for _, link := range links {
+			if ctx.Err() != nil {
+				break
+			}
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(link models.LinkCheck) {
+				defer func() { <-sem; wg.Done() }()
+
+				check := c.Check(ctx, link)
+				if ctx.Err() != nil {
+					return
+				}
+				err := c.repos.SaveLinkCheck(check, c.nextCheck(check))
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					saveErr = err
+					return
+				}
+				checked++
+				if check.Broken() {
+					broken++
+				}
+			}(link)
+		}
+		wg.Wait()
+
+		switch {
+		case ctx.Err() != nil:
+			return checked, broken, ctx.Err()
+		case saveErr != nil:
+			// Unsaved links stay due, so loading another batch would return them again.
+			return checked, broken, saveErr
+		case len(links) < BatchSize:
+			return checked, broken, nil
+		}
+	}
+}
+
+// Check requests the link with HEAD, then with GET if that fails, as some servers reject HEAD.
+func (c *Checker) Check(ctx context.Context, link models.LinkCheck) models.LinkCheck {
+	status, final, err := c.request(ctx, http.MethodHead, link.Link)
+	if err != nil || status >= 400 {
+		status, final, err = c.request(ctx, http.MethodGet, link.Link)
+	}
+
+	now := time.Now()
+	check := models.LinkCheck{
+		MaterialId: link.MaterialId,
+		Name:       link.Name,
+		Link:       link.Link,
+		StatusCode: status,
+		CheckedAt:  &now,
+	}
+	if err != nil {
+		check.LastError = err.Error()
+	}
+	if final != "" && final != link.Link {
+		check.RedirectTo = final
+	}
+	if check.Broken() {
+		check.Failures = link.Failures + 1
+	}
+	return check
+}
+
+func (c *Checker) request(ctx context.Context, method, link string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("User-Agent", "SkillBuilder/1.0 (+link checker)")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// The method and link are already known, so only the cause is kept.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, "", err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, resp.Request.URL.String(), nil
+}
+
+// nextCheck schedules a working link an interval ahead, and backs off exponentially from a
+// link that keeps failing.
+func (c *Checker) nextCheck(check models.LinkCheck) time.Time {
+	wait := c.interval
+	for i := 1; i < check.Failures && wait < MaxBackoff; i++ {
+		wait *= 2
+	}
+	return check.CheckedAt.Add(min(wait, MaxBackoff))
+}
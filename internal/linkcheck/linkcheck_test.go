@@ -0,0 +1,115 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"github.com/grafchitaru/skillBuilder/internal/importer"
+	"github.com/grafchitaru/skillBuilder/internal/mocks"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newFakeSite(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheck(t *testing.T) {
+	server := newFakeSite(t)
+	checker := New(&mocks.MockStorage{}, importer.NewClient(true), time.Hour, 4)
+
+	check := checker.Check(context.Background(), models.LinkCheck{MaterialId: "m1", Link: server.URL + "/ok", Failures: 2})
+	assert.False(t, check.Broken())
+	assert.Equal(t, http.StatusOK, check.StatusCode)
+	assert.Zero(t, check.Failures)
+	assert.NotNil(t, check.CheckedAt)
+
+	check = checker.Check(context.Background(), models.LinkCheck{MaterialId: "m2", Link: server.URL + "/moved"})
+	assert.False(t, check.Broken())
+	assert.Equal(t, server.URL+"/ok", check.RedirectTo)
+
+	check = checker.Check(context.Background(), models.LinkCheck{MaterialId: "m3", Link: server.URL + "/no-head"})
+	assert.False(t, check.Broken())
+
+	check = checker.Check(context.Background(), models.LinkCheck{MaterialId: "m4", Link: server.URL + "/gone", Failures: 1})
+	assert.True(t, check.Broken())
+	assert.Equal(t, http.StatusNotFound, check.StatusCode)
+	assert.Equal(t, 2, check.Failures)
+
+	check = checker.Check(context.Background(), models.LinkCheck{MaterialId: "m5", Link: "http://127.0.0.1:1/"})
+	assert.True(t, check.Broken())
+	assert.NotEmpty(t, check.LastError)
+}
+
+func TestCheckAll(t *testing.T) {
+	server := newFakeSite(t)
+	var mu sync.Mutex
+	saved := map[string]time.Duration{}
+	mockStorage := &mocks.MockStorage{
+		GetLinksToCheckFunc: func(limit int) ([]models.LinkCheck, error) {
+			assert.Equal(t, BatchSize, limit)
+			return []models.LinkCheck{
+				{MaterialId: "ok", Link: server.URL + "/ok"},
+				{MaterialId: "gone", Link: server.URL + "/gone", Failures: 2},
+			}, nil
+		},
+		SaveLinkCheckFunc: func(check models.LinkCheck, nextCheck time.Time) error {
+			mu.Lock()
+			defer mu.Unlock()
+			saved[check.MaterialId] = nextCheck.Sub(*check.CheckedAt)
+			return nil
+		},
+	}
+
+	checked, broken, err := New(mockStorage, importer.NewClient(true), time.Hour, 2).CheckAll(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, checked)
+	assert.Equal(t, 1, broken)
+	assert.Equal(t, map[string]time.Duration{"ok": time.Hour, "gone": 4 * time.Hour}, saved)
+}
+
+func TestCheckAll_SaveError(t *testing.T) {
+	server := newFakeSite(t)
+	mockStorage := &mocks.MockStorage{
+		GetLinksToCheckFunc: func(limit int) ([]models.LinkCheck, error) {
+			return []models.LinkCheck{{MaterialId: "ok", Link: server.URL + "/ok"}}, nil
+		},
+		SaveLinkCheckFunc: func(check models.LinkCheck, nextCheck time.Time) error {
+			return errors.New("save error")
+		},
+	}
+
+	checked, _, err := New(mockStorage, importer.NewClient(true), time.Hour, 2).CheckAll(context.Background())
+
+	assert.Error(t, err)
+	assert.Zero(t, checked)
+}
+
+func TestNextCheck(t *testing.T) {
+	checker := New(&mocks.MockStorage{}, http.DefaultClient, 24*time.Hour, 1)
+	now := time.Now()
+
+	assert.Equal(t, now.Add(24*time.Hour), checker.nextCheck(models.LinkCheck{CheckedAt: &now}))
+	assert.Equal(t, now.Add(48*time.Hour), checker.nextCheck(models.LinkCheck{CheckedAt: &now, Failures: 2}))
+	assert.Equal(t, now.Add(MaxBackoff), checker.nextCheck(models.LinkCheck{CheckedAt: &now, Failures: 20}))
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"errors"
 	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
 )
 
 type GetUserFunc func(login string) (string, error)
@@ -10,51 +11,153 @@ type GetUserPasswordFunc func(login string) (string, error)
 type RegistrationFunc func(id string, login string, password string) (string, error)
 type CreateCollectionFunc func(userID string, name string, description string) (string, error)
 type CreateMaterialFunc func(userID string, name string, description string, typed string, xp int, link string) (string, error)
-type DeleteCollectionFunc func(userID, collectionID string) error
+type DeleteCollectionFunc func(collectionID string) error
 type UpdateCollectionFunc func(collection models.Collection) error
 type AddMaterialToCollectionFunc func(collectionID, materialID string) error
+type RemoveMaterialFromCollectionFunc func(collectionID, materialID string) error
+type SetMaterialOrderFunc func(collectionID string, materialIDs []string) error
+type ImportMaterialsFunc func(collectionID, userID string, materials []models.NewMaterial) ([]string, error)
 type UpdateMaterialFunc func(material models.Material) error
-type DeleteMaterialFunc func(userID, materialID string) error
-type GetCollectionsFunc func(userID string) ([]models.Collection, error)
-type GetUserCollectionsFunc func(userID string) ([]models.Collection, error)
+type DeleteMaterialFunc func(materialID string) error
+type GetCollectionsFunc func(userID string, filter models.CollectionFilter) (models.CollectionList, error)
+type GetUserCollectionsFunc func(userID string, page models.Page) (models.CollectionList, error)
 type GetCollectionFunc func(collectionID string, userID string) (models.Collection, error)
-type GetMaterialFunc func(materialID string) (models.Material, error)
-type GetMaterialsFunc func(collectionID string) ([]models.Material, error)
+type GetMaterialFunc func(materialID, userID string) (models.Material, error)
+type GetMaterialsFunc func(collectionID string, userID string, filter models.MaterialFilter) (models.MaterialList, error)
 type AddCollectionToUserFunc func(userID, collectionID string) error
 type DeleteCollectionFromUserFunc func(userID, collectionID string) error
 type MarkMaterialAsCompletedFunc func(userID, materialID string) error
 type MarkMaterialAsNotCompletedFunc func(userID, materialID string) error
-type SearchMaterialsFunc func(query string) ([]models.Material, error)
-type SearchCollectionsFunc func(query string, userID string) ([]models.Collection, error)
-type GetTypeMaterialsFunc func() ([]models.TypeMaterial, error)
+type SearchMaterialsFunc func(search models.SearchQuery, userID string) (models.MaterialList, error)
+type SearchCollectionsFunc func(search models.SearchQuery, userID string) (models.CollectionList, error)
+type GetTypeMaterialsFunc func(page models.Page) (models.TypeMaterialList, error)
+type ForkCollectionFunc func(userID, collectionID string, keepProgress bool) (string, error)
+type GetCollectionRoleFunc func(collectionID, userID string) (string, error)
+type GetMaterialRoleFunc func(materialID, userID string) (string, error)
+type AddCollaboratorFunc func(collectionID, userID, role string) error
+type DeleteCollaboratorFunc func(collectionID, userID string) error
+type GetCollaboratorsFunc func(collectionID string) ([]models.Collaborator, error)
+type SetCollectionTagsFunc func(collectionID string, names []string) error
+type SetMaterialTagsFunc func(materialID string, names []string) error
+type GetTagsFunc func(curatedOnly bool) ([]models.Tag, error)
+type SaveReviewFunc func(collectionID, userID string, rating int, text string) error
+type DeleteReviewFunc func(collectionID, userID string) error
+type GetReviewsFunc func(collectionID, userID string) ([]models.Review, error)
+type CreateCommentFunc func(materialID, userID, parentID, text string) (string, error)
+type GetCommentFunc func(commentID string) (models.Comment, error)
+type GetCommentsFunc func(materialID, userID string, page models.Page) (models.CommentList, error)
+type UpdateCommentFunc func(commentID, text string) error
+type DeleteCommentFunc func(commentID string) error
+type HideCommentFunc func(commentID, moderatorID string) error
+type SuggestFunc func(query, userID string, limit int) (models.Suggestions, error)
+type SetCollectionVisibilityFunc func(collectionID string, isPublic bool) error
+type CreateSavedSearchFunc func(userID, name string, query models.SearchQuery) (string, error)
+type GetSavedSearchesFunc func(userID string) ([]models.SavedSearch, error)
+type GetAllSavedSearchesFunc func() ([]models.SavedSearch, error)
+type DeleteSavedSearchFunc func(searchID, userID string) error
+type CheckSavedSearchFunc func(search models.SavedSearch) (int, error)
+type GetNotificationsFunc func(userID string, unreadOnly bool) ([]models.Notification, error)
+type MarkNotificationReadFunc func(notificationID, userID string) error
+type SearchFacetsFunc func(search models.SearchQuery, userID string) (models.SearchFacets, error)
+type CreateFeedFunc func(collectionID, userID, url, title string) (string, error)
+type GetFeedsFunc func(collectionID, userID string) ([]models.Feed, error)
+type GetAllFeedsFunc func() ([]models.Feed, error)
+type DeleteFeedFunc func(feedID, collectionID string) error
+type AddFeedEntriesFunc func(feed models.Feed, entries []models.FeedEntry) (int, error)
+type UpdateFeedStatusFunc func(feedID, title, lastError string) error
+type GetLinksToCheckFunc func(limit int) ([]models.LinkCheck, error)
+type SaveLinkCheckFunc func(check models.LinkCheck, nextCheck time.Time) error
+type GetCollectionHealthFunc func(collectionID string) (models.CollectionHealth, error)
+type GetRecommendationCorpusFunc func(userID string) (models.RecommendationCorpus, error)
+type GetCollectionEngagementFunc func() ([]models.Engagement, error)
+type ReplaceRelatedCollectionsFunc func(similarities []models.CollectionSimilarity) error
+type GetRelatedCollectionsFunc func(collectionID, userID string, limit int) ([]models.RelatedCollection, error)
+type GetNextCandidatesFunc func(userID string) ([]models.NextCandidate, error)
+type RefreshCollectionRankingFunc func(kind string, since time.Time) (int, error)
+type GetRankedCollectionsFunc func(kind, userID string, page models.Page) (models.RankedCollectionList, error)
+type GetAccountArchiveFunc func(userID string) (models.Archive, error)
+type ImportAccountArchiveFunc func(userID string, archive models.Archive) (models.ArchiveImportResult, error)
+type ImportSectionFunc func(collectionID, userID, name string, materials []models.NewMaterial) (string, []string, error)
+type GetSectionsFunc func(collectionID, userID string) ([]models.Section, error)
 
 type MockStorage struct {
-	PingError                      error
-	GetUserFunc                    GetUserFunc
-	RegistrationFunc               RegistrationFunc
-	GetUserPasswordFunc            GetUserPasswordFunc
-	Users                          map[string]string
-	IDs                            map[string]string
-	Passwords                      map[string]string
-	CreateCollectionFunc           CreateCollectionFunc
-	CreateMaterialFunc             CreateMaterialFunc
-	DeleteCollectionFunc           DeleteCollectionFunc
-	UpdateCollectionFunc           UpdateCollectionFunc
-	AddMaterialToCollectionFunc    AddMaterialToCollectionFunc
-	UpdateMaterialFunc             UpdateMaterialFunc
-	DeleteMaterialFunc             DeleteMaterialFunc
-	GetCollectionsFunc             GetCollectionsFunc
-	GetUserCollectionsFunc         GetUserCollectionsFunc
-	GetCollectionFunc              GetCollectionFunc
-	GetMaterialFunc                GetMaterialFunc
-	GetMaterialsFunc               GetMaterialsFunc
-	AddCollectionToUserFunc        AddCollectionToUserFunc
-	DeleteCollectionFromUserFunc   DeleteCollectionFromUserFunc
-	MarkMaterialAsCompletedFunc    MarkMaterialAsCompletedFunc
-	MarkMaterialAsNotCompletedFunc MarkMaterialAsNotCompletedFunc
-	SearchMaterialsFunc            SearchMaterialsFunc
-	SearchCollectionsFunc          SearchCollectionsFunc
-	GetTypeMaterialsFunc           GetTypeMaterialsFunc
+	PingError                        error
+	GetUserFunc                      GetUserFunc
+	RegistrationFunc                 RegistrationFunc
+	GetUserPasswordFunc              GetUserPasswordFunc
+	Users                            map[string]string
+	IDs                              map[string]string
+	Passwords                        map[string]string
+	CreateCollectionFunc             CreateCollectionFunc
+	CreateMaterialFunc               CreateMaterialFunc
+	DeleteCollectionFunc             DeleteCollectionFunc
+	UpdateCollectionFunc             UpdateCollectionFunc
+	AddMaterialToCollectionFunc      AddMaterialToCollectionFunc
+	RemoveMaterialFromCollectionFunc RemoveMaterialFromCollectionFunc
+	SetMaterialOrderFunc             SetMaterialOrderFunc
+	ImportMaterialsFunc              ImportMaterialsFunc
+	UpdateMaterialFunc               UpdateMaterialFunc
+	DeleteMaterialFunc               DeleteMaterialFunc
+	GetCollectionsFunc               GetCollectionsFunc
+	GetUserCollectionsFunc           GetUserCollectionsFunc
+	GetCollectionFunc                GetCollectionFunc
+	GetMaterialFunc                  GetMaterialFunc
+	GetMaterialsFunc                 GetMaterialsFunc
+	AddCollectionToUserFunc          AddCollectionToUserFunc
+	DeleteCollectionFromUserFunc     DeleteCollectionFromUserFunc
+	MarkMaterialAsCompletedFunc      MarkMaterialAsCompletedFunc
+	MarkMaterialAsNotCompletedFunc   MarkMaterialAsNotCompletedFunc
+	SearchMaterialsFunc              SearchMaterialsFunc
+	SearchCollectionsFunc            SearchCollectionsFunc
+	GetTypeMaterialsFunc             GetTypeMaterialsFunc
+	ForkCollectionFunc               ForkCollectionFunc
+	GetCollectionRoleFunc            GetCollectionRoleFunc
+	GetMaterialRoleFunc              GetMaterialRoleFunc
+	AddCollaboratorFunc              AddCollaboratorFunc
+	DeleteCollaboratorFunc           DeleteCollaboratorFunc
+	GetCollaboratorsFunc             GetCollaboratorsFunc
+	SetCollectionTagsFunc            SetCollectionTagsFunc
+	SetMaterialTagsFunc              SetMaterialTagsFunc
+	GetTagsFunc                      GetTagsFunc
+	SaveReviewFunc                   SaveReviewFunc
+	DeleteReviewFunc                 DeleteReviewFunc
+	GetReviewsFunc                   GetReviewsFunc
+	CreateCommentFunc                CreateCommentFunc
+	GetCommentFunc                   GetCommentFunc
+	GetCommentsFunc                  GetCommentsFunc
+	UpdateCommentFunc                UpdateCommentFunc
+	DeleteCommentFunc                DeleteCommentFunc
+	HideCommentFunc                  HideCommentFunc
+	SuggestFunc                      SuggestFunc
+	SearchFacetsFunc                 SearchFacetsFunc
+	SetCollectionVisibilityFunc      SetCollectionVisibilityFunc
+	CreateSavedSearchFunc            CreateSavedSearchFunc
+	GetSavedSearchesFunc             GetSavedSearchesFunc
+	GetAllSavedSearchesFunc          GetAllSavedSearchesFunc
+	DeleteSavedSearchFunc            DeleteSavedSearchFunc
+	CheckSavedSearchFunc             CheckSavedSearchFunc
+	GetNotificationsFunc             GetNotificationsFunc
+	MarkNotificationReadFunc         MarkNotificationReadFunc
+	CreateFeedFunc                   CreateFeedFunc
+	GetFeedsFunc                     GetFeedsFunc
+	GetAllFeedsFunc                  GetAllFeedsFunc
+	DeleteFeedFunc                   DeleteFeedFunc
+	AddFeedEntriesFunc               AddFeedEntriesFunc
+	UpdateFeedStatusFunc             UpdateFeedStatusFunc
+	GetAccountArchiveFunc            GetAccountArchiveFunc
+	ImportAccountArchiveFunc         ImportAccountArchiveFunc
+	ImportSectionFunc                ImportSectionFunc
+	GetSectionsFunc                  GetSectionsFunc
+	GetLinksToCheckFunc              GetLinksToCheckFunc
+	SaveLinkCheckFunc                SaveLinkCheckFunc
+	GetCollectionHealthFunc          GetCollectionHealthFunc
+	GetRecommendationCorpusFunc      GetRecommendationCorpusFunc
+	GetCollectionEngagementFunc      GetCollectionEngagementFunc
+	ReplaceRelatedCollectionsFunc    ReplaceRelatedCollectionsFunc
+	GetRelatedCollectionsFunc        GetRelatedCollectionsFunc
+	GetNextCandidatesFunc            GetNextCandidatesFunc
+	RefreshCollectionRankingFunc     RefreshCollectionRankingFunc
+	GetRankedCollectionsFunc         GetRankedCollectionsFunc
 }
 
 func NewMockStorage() *MockStorage {
@@ -125,9 +228,9 @@ func (ms *MockStorage) CreateMaterial(userID string, name string, description st
 	return "", errors.New("not implemented")
 }
 
-func (ms *MockStorage) DeleteCollection(userID, collectionID string) error {
+func (ms *MockStorage) DeleteCollection(collectionID string) error {
 	if ms.DeleteCollectionFunc != nil {
-		return ms.DeleteCollectionFunc(userID, collectionID)
+		return ms.DeleteCollectionFunc(collectionID)
 	}
 	return errors.New("not implemented")
 }
@@ -146,6 +249,27 @@ func (ms *MockStorage) AddMaterialToCollection(collectionID, materialID string)
 	return errors.New("not implemented")
 }
 
+func (ms *MockStorage) RemoveMaterialFromCollection(collectionID, materialID string) error {
+	if ms.RemoveMaterialFromCollectionFunc != nil {
+		return ms.RemoveMaterialFromCollectionFunc(collectionID, materialID)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) SetMaterialOrder(collectionID string, materialIDs []string) error {
+	if ms.SetMaterialOrderFunc != nil {
+		return ms.SetMaterialOrderFunc(collectionID, materialIDs)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) ImportMaterials(collectionID, userID string, materials []models.NewMaterial) ([]string, error) {
+	if ms.ImportMaterialsFunc != nil {
+		return ms.ImportMaterialsFunc(collectionID, userID, materials)
+	}
+	return []string{}, errors.New("not implemented")
+}
+
 func (ms *MockStorage) UpdateMaterial(material models.Material) error {
 	if ms.UpdateMaterialFunc != nil {
 		return ms.UpdateMaterialFunc(material)
@@ -153,25 +277,25 @@ func (ms *MockStorage) UpdateMaterial(material models.Material) error {
 	return errors.New("not implemented")
 }
 
-func (ms *MockStorage) DeleteMaterial(userID, materialID string) error {
+func (ms *MockStorage) DeleteMaterial(materialID string) error {
 	if ms.DeleteMaterialFunc != nil {
-		return ms.DeleteMaterialFunc(userID, materialID)
+		return ms.DeleteMaterialFunc(materialID)
 	}
 	return errors.New("not implemented")
 }
 
-func (ms *MockStorage) GetCollections(userID string) ([]models.Collection, error) {
+func (ms *MockStorage) GetCollections(userID string, filter models.CollectionFilter) (models.CollectionList, error) {
 	if ms.GetCollectionsFunc != nil {
-		return ms.GetCollectionsFunc(userID)
+		return ms.GetCollectionsFunc(userID, filter)
 	}
-	return nil, errors.New("not implemented")
+	return models.CollectionList{}, errors.New("not implemented")
 }
 
-func (ms *MockStorage) GetUserCollections(userID string) ([]models.Collection, error) {
+func (ms *MockStorage) GetUserCollections(userID string, page models.Page) (models.CollectionList, error) {
 	if ms.GetUserCollectionsFunc != nil {
-		return ms.GetUserCollectionsFunc(userID)
+		return ms.GetUserCollectionsFunc(userID, page)
 	}
-	return nil, errors.New("not implemented")
+	return models.CollectionList{}, errors.New("not implemented")
 }
 
 func (ms *MockStorage) GetCollection(collectionID string, userID string) (models.Collection, error) {
@@ -181,18 +305,18 @@ func (ms *MockStorage) GetCollection(collectionID string, userID string) (models
 	return models.Collection{}, errors.New("not implemented")
 }
 
-func (ms *MockStorage) GetMaterial(materialID string) (models.Material, error) {
+func (ms *MockStorage) GetMaterial(materialID, userID string) (models.Material, error) {
 	if ms.GetMaterialFunc != nil {
-		return ms.GetMaterialFunc(materialID)
+		return ms.GetMaterialFunc(materialID, userID)
 	}
 	return models.Material{}, errors.New("not implemented")
 }
 
-func (ms *MockStorage) GetMaterials(collectionID string, userID string) ([]models.Material, error) {
+func (ms *MockStorage) GetMaterials(collectionID string, userID string, filter models.MaterialFilter) (models.MaterialList, error) {
 	if ms.GetMaterialsFunc != nil {
-		return ms.GetMaterialsFunc(collectionID)
+		return ms.GetMaterialsFunc(collectionID, userID, filter)
 	}
-	return []models.Material{}, errors.New("not implemented")
+	return models.MaterialList{}, errors.New("not implemented")
 }
 
 func (ms *MockStorage) AddCollectionToUser(userID, collectionID string) error {
@@ -223,23 +347,359 @@ func (ms *MockStorage) MarkMaterialAsNotCompleted(userID, materialID string) err
 	return errors.New("not implemented")
 }
 
-func (ms *MockStorage) SearchMaterials(query string) ([]models.Material, error) {
+func (ms *MockStorage) SearchMaterials(search models.SearchQuery, userID string) (models.MaterialList, error) {
 	if ms.SearchMaterialsFunc != nil {
-		return ms.SearchMaterialsFunc(query)
+		return ms.SearchMaterialsFunc(search, userID)
 	}
-	return []models.Material{}, errors.New("not implemented")
+	return models.MaterialList{}, errors.New("not implemented")
 }
 
-func (ms *MockStorage) SearchCollections(query string, userID string) ([]models.Collection, error) {
+func (ms *MockStorage) SearchCollections(search models.SearchQuery, userID string) (models.CollectionList, error) {
 	if ms.SearchCollectionsFunc != nil {
-		return ms.SearchCollectionsFunc(query, userID)
+		return ms.SearchCollectionsFunc(search, userID)
 	}
-	return []models.Collection{}, errors.New("not implemented")
+	return models.CollectionList{}, errors.New("not implemented")
 }
 
-func (ms *MockStorage) GetTypeMaterials() ([]models.TypeMaterial, error) {
+func (ms *MockStorage) GetTypeMaterials(page models.Page) (models.TypeMaterialList, error) {
 	if ms.GetTypeMaterialsFunc != nil {
-		return ms.GetTypeMaterialsFunc()
+		return ms.GetTypeMaterialsFunc(page)
+	}
+	return models.TypeMaterialList{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) ForkCollection(userID, collectionID string, keepProgress bool) (string, error) {
+	if ms.ForkCollectionFunc != nil {
+		return ms.ForkCollectionFunc(userID, collectionID, keepProgress)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetCollectionRole(collectionID, userID string) (string, error) {
+	if ms.GetCollectionRoleFunc != nil {
+		return ms.GetCollectionRoleFunc(collectionID, userID)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetMaterialRole(materialID, userID string) (string, error) {
+	if ms.GetMaterialRoleFunc != nil {
+		return ms.GetMaterialRoleFunc(materialID, userID)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (ms *MockStorage) AddCollaborator(collectionID, userID, role string) error {
+	if ms.AddCollaboratorFunc != nil {
+		return ms.AddCollaboratorFunc(collectionID, userID, role)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) DeleteCollaborator(collectionID, userID string) error {
+	if ms.DeleteCollaboratorFunc != nil {
+		return ms.DeleteCollaboratorFunc(collectionID, userID)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetCollaborators(collectionID string) ([]models.Collaborator, error) {
+	if ms.GetCollaboratorsFunc != nil {
+		return ms.GetCollaboratorsFunc(collectionID)
+	}
+	return []models.Collaborator{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) SetCollectionTags(collectionID string, names []string) error {
+	if ms.SetCollectionTagsFunc != nil {
+		return ms.SetCollectionTagsFunc(collectionID, names)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) SetMaterialTags(materialID string, names []string) error {
+	if ms.SetMaterialTagsFunc != nil {
+		return ms.SetMaterialTagsFunc(materialID, names)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetTags(curatedOnly bool) ([]models.Tag, error) {
+	if ms.GetTagsFunc != nil {
+		return ms.GetTagsFunc(curatedOnly)
+	}
+	return []models.Tag{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) SaveReview(collectionID, userID string, rating int, text string) error {
+	if ms.SaveReviewFunc != nil {
+		return ms.SaveReviewFunc(collectionID, userID, rating, text)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) DeleteReview(collectionID, userID string) error {
+	if ms.DeleteReviewFunc != nil {
+		return ms.DeleteReviewFunc(collectionID, userID)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetReviews(collectionID, userID string) ([]models.Review, error) {
+	if ms.GetReviewsFunc != nil {
+		return ms.GetReviewsFunc(collectionID, userID)
+	}
+	return []models.Review{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) CreateComment(materialID, userID, parentID, text string) (string, error) {
+	if ms.CreateCommentFunc != nil {
+		return ms.CreateCommentFunc(materialID, userID, parentID, text)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetComment(commentID string) (models.Comment, error) {
+	if ms.GetCommentFunc != nil {
+		return ms.GetCommentFunc(commentID)
+	}
+	return models.Comment{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetComments(materialID, userID string, page models.Page) (models.CommentList, error) {
+	if ms.GetCommentsFunc != nil {
+		return ms.GetCommentsFunc(materialID, userID, page)
+	}
+	return models.CommentList{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) UpdateComment(commentID, text string) error {
+	if ms.UpdateCommentFunc != nil {
+		return ms.UpdateCommentFunc(commentID, text)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) DeleteComment(commentID string) error {
+	if ms.DeleteCommentFunc != nil {
+		return ms.DeleteCommentFunc(commentID)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) HideComment(commentID, moderatorID string) error {
+	if ms.HideCommentFunc != nil {
+		return ms.HideCommentFunc(commentID, moderatorID)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) Suggest(query, userID string, limit int) (models.Suggestions, error) {
+	if ms.SuggestFunc != nil {
+		return ms.SuggestFunc(query, userID, limit)
+	}
+	return models.Suggestions{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) SearchFacets(search models.SearchQuery, userID string) (models.SearchFacets, error) {
+	if ms.SearchFacetsFunc != nil {
+		return ms.SearchFacetsFunc(search, userID)
+	}
+	return models.SearchFacets{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) SetCollectionVisibility(collectionID string, isPublic bool) error {
+	if ms.SetCollectionVisibilityFunc != nil {
+		return ms.SetCollectionVisibilityFunc(collectionID, isPublic)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) CreateSavedSearch(userID, name string, query models.SearchQuery) (string, error) {
+	if ms.CreateSavedSearchFunc != nil {
+		return ms.CreateSavedSearchFunc(userID, name, query)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetSavedSearches(userID string) ([]models.SavedSearch, error) {
+	if ms.GetSavedSearchesFunc != nil {
+		return ms.GetSavedSearchesFunc(userID)
+	}
+	return []models.SavedSearch{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetAllSavedSearches() ([]models.SavedSearch, error) {
+	if ms.GetAllSavedSearchesFunc != nil {
+		return ms.GetAllSavedSearchesFunc()
+	}
+	return []models.SavedSearch{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) DeleteSavedSearch(searchID, userID string) error {
+	if ms.DeleteSavedSearchFunc != nil {
+		return ms.DeleteSavedSearchFunc(searchID, userID)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) CheckSavedSearch(search models.SavedSearch) (int, error) {
+	if ms.CheckSavedSearchFunc != nil {
+		return ms.CheckSavedSearchFunc(search)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetNotifications(userID string, unreadOnly bool) ([]models.Notification, error) {
+	if ms.GetNotificationsFunc != nil {
+		return ms.GetNotificationsFunc(userID, unreadOnly)
+	}
+	return []models.Notification{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) MarkNotificationRead(notificationID, userID string) error {
+	if ms.MarkNotificationReadFunc != nil {
+		return ms.MarkNotificationReadFunc(notificationID, userID)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetAccountArchive(userID string) (models.Archive, error) {
+	if ms.GetAccountArchiveFunc != nil {
+		return ms.GetAccountArchiveFunc(userID)
+	}
+	return models.Archive{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) ImportAccountArchive(userID string, archive models.Archive) (models.ArchiveImportResult, error) {
+	if ms.ImportAccountArchiveFunc != nil {
+		return ms.ImportAccountArchiveFunc(userID, archive)
+	}
+	return models.ArchiveImportResult{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) ImportSection(collectionID, userID, name string, materials []models.NewMaterial) (string, []string, error) {
+	if ms.ImportSectionFunc != nil {
+		return ms.ImportSectionFunc(collectionID, userID, name, materials)
+	}
+	return "", []string{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetSections(collectionID, userID string) ([]models.Section, error) {
+	if ms.GetSectionsFunc != nil {
+		return ms.GetSectionsFunc(collectionID, userID)
+	}
+	return []models.Section{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) CreateFeed(collectionID, userID, url, title string) (string, error) {
+	if ms.CreateFeedFunc != nil {
+		return ms.CreateFeedFunc(collectionID, userID, url, title)
+	}
+	return "", errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetFeeds(collectionID, userID string) ([]models.Feed, error) {
+	if ms.GetFeedsFunc != nil {
+		return ms.GetFeedsFunc(collectionID, userID)
+	}
+	return []models.Feed{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetAllFeeds() ([]models.Feed, error) {
+	if ms.GetAllFeedsFunc != nil {
+		return ms.GetAllFeedsFunc()
+	}
+	return []models.Feed{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) DeleteFeed(feedID, collectionID string) error {
+	if ms.DeleteFeedFunc != nil {
+		return ms.DeleteFeedFunc(feedID, collectionID)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) AddFeedEntries(feed models.Feed, entries []models.FeedEntry) (int, error) {
+	if ms.AddFeedEntriesFunc != nil {
+		return ms.AddFeedEntriesFunc(feed, entries)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (ms *MockStorage) UpdateFeedStatus(feedID, title, lastError string) error {
+	if ms.UpdateFeedStatusFunc != nil {
+		return ms.UpdateFeedStatusFunc(feedID, title, lastError)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetLinksToCheck(limit int) ([]models.LinkCheck, error) {
+	if ms.GetLinksToCheckFunc != nil {
+		return ms.GetLinksToCheckFunc(limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (ms *MockStorage) SaveLinkCheck(check models.LinkCheck, nextCheck time.Time) error {
+	if ms.SaveLinkCheckFunc != nil {
+		return ms.SaveLinkCheckFunc(check, nextCheck)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetCollectionHealth(collectionID string) (models.CollectionHealth, error) {
+	if ms.GetCollectionHealthFunc != nil {
+		return ms.GetCollectionHealthFunc(collectionID)
+	}
+	return models.CollectionHealth{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetRecommendationCorpus(userID string) (models.RecommendationCorpus, error) {
+	if ms.GetRecommendationCorpusFunc != nil {
+		return ms.GetRecommendationCorpusFunc(userID)
+	}
+	return models.RecommendationCorpus{}, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetCollectionEngagement() ([]models.Engagement, error) {
+	if ms.GetCollectionEngagementFunc != nil {
+		return ms.GetCollectionEngagementFunc()
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (ms *MockStorage) ReplaceRelatedCollections(similarities []models.CollectionSimilarity) error {
+	if ms.ReplaceRelatedCollectionsFunc != nil {
+		return ms.ReplaceRelatedCollectionsFunc(similarities)
+	}
+	return errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetRelatedCollections(collectionID, userID string, limit int) ([]models.RelatedCollection, error) {
+	if ms.GetRelatedCollectionsFunc != nil {
+		return ms.GetRelatedCollectionsFunc(collectionID, userID, limit)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetNextCandidates(userID string) ([]models.NextCandidate, error) {
+	if ms.GetNextCandidatesFunc != nil {
+		return ms.GetNextCandidatesFunc(userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (ms *MockStorage) RefreshCollectionRanking(kind string, since time.Time) (int, error) {
+	if ms.RefreshCollectionRankingFunc != nil {
+		return ms.RefreshCollectionRankingFunc(kind, since)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (ms *MockStorage) GetRankedCollections(kind, userID string, page models.Page) (models.RankedCollectionList, error) {
+	if ms.GetRankedCollectionsFunc != nil {
+		return ms.GetRankedCollectionsFunc(kind, userID, page)
 	}
-	return []models.TypeMaterial{}, errors.New("not implemented")
+	return models.RankedCollectionList{}, errors.New("not implemented")
 }
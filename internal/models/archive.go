@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// ArchiveVersion is the version of the account archive format written by this build.
+const ArchiveVersion = 1
+
+type Archive struct {
+	Version     int                 `json:"version"`
+	ExportedAt  time.Time           `json:"exported_at"`
+	Collections []ArchiveCollection `json:"collections"`
+	Materials   []ArchiveMaterial   `json:"materials"`
+	Memberships []string            `json:"memberships"`
+	Completions []ArchiveCompletion `json:"completions"`
+}
+
+type ArchiveCollection struct {
+	Id          string    `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsPublic    bool      `json:"is_public"`
+	Tags        []string  `json:"tags"`
+	Materials   []string  `json:"materials"`
+}
+
+type ArchiveMaterial struct {
+	Id          string    `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Type        string    `json:"type"`
+	Xp          int       `json:"xp"`
+	Link        string    `json:"link"`
+	Tags        []string  `json:"tags"`
+}
+
+type ArchiveCompletion struct {
+	MaterialId  string     `json:"material_id"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type ImportCount struct {
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+}
+
+type ArchiveImportResult struct {
+	Collections ImportCount `json:"collections"`
+	Materials   ImportCount `json:"materials"`
+	Memberships ImportCount `json:"memberships"`
+	Completions ImportCount `json:"completions"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// Book is the metadata of a book edition found by ISBN.
+type Book struct {
+	ISBN        string   `json:"isbn"`
+	Title       string   `json:"title"`
+	Authors     []string `json:"authors"`
+	Description string   `json:"description"`
+	Pages       int      `json:"pages"`
+	Link        string   `json:"link"`
+}
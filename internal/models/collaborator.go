@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type NewCollaborator struct {
+	Login string `json:"login"`
+	Role  string `json:"role"`
+}
+
+type Collaborator struct {
+	CollectionId string    `json:"collection_id"`
+	UserId       string    `json:"user_id"`
+	Login        string    `json:"login"`
+	Role         string    `json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
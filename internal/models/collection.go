@@ -6,17 +6,47 @@ import (
 )
 
 type NewCollection struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	IsPublic    *bool    `json:"is_public"`
 }
 
 type Collection struct {
-	Id          string        `json:"id"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
-	UserId      string        `json:"user_id"`
-	Name        string        `json:"name"`
-	Description string        `json:"description"`
-	SumXp       sql.NullInt64 `json:"sum_xp"`
-	Xp          sql.NullInt64 `json:"xp"`
+	Id          string              `json:"id"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	UserId      string              `json:"user_id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	ForkedFrom  sql.NullString      `json:"forked_from"`
+	IsPublic    bool                `json:"is_public"`
+	Tags        []string            `json:"tags"`
+	Rating      float64             `json:"rating"`
+	RatingCount int                 `json:"rating_count"`
+	SumXp       sql.NullInt64       `json:"sum_xp"`
+	Xp          sql.NullInt64       `json:"xp"`
+	Rank        float64             `json:"rank,omitempty"`
+	Highlight   string              `json:"highlight,omitempty"`
+	Related     []RelatedCollection `json:"related,omitempty"`
+}
+
+type CollectionRef struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CollectionVisibility struct {
+	IsPublic bool `json:"is_public"`
+}
+
+type ForkCollection struct {
+	KeepProgress bool `json:"keep_progress"`
+}
+
+const SortRating = "rating"
+
+type CollectionFilter struct {
+	Tags []string
+	Page
 }
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type NewComment struct {
+	ParentId string `json:"parent_id"`
+	Text     string `json:"text"`
+}
+
+type Comment struct {
+	Id         string         `json:"id"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	MaterialId string         `json:"material_id"`
+	UserId     string         `json:"user_id"`
+	Login      string         `json:"login"`
+	ParentId   sql.NullString `json:"parent_id"`
+	Text       string         `json:"text"`
+	Deleted    bool           `json:"deleted"`
+	Replies    []Comment      `json:"replies"`
+}
+
+type CommentList struct {
+	Comments   []Comment `json:"comments"`
+	Total      int       `json:"total"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Feed statuses: not fetched by the poller yet, fetched fine, or failing with LastError.
+const (
+	FeedPending = "pending"
+	FeedOK      = "ok"
+	FeedError   = "error"
+)
+
+type Feed struct {
+	Id           string     `json:"id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CollectionId string     `json:"collection_id"`
+	UserId       string     `json:"user_id"`
+	URL          string     `json:"url"`
+	Title        string     `json:"title"`
+	Status       string     `json:"status"`
+	CheckedAt    *time.Time `json:"checked_at"`
+	LastError    string     `json:"last_error"`
+	Entries      int        `json:"entries"`
+}
+
+// FeedEntry is a feed item to add as a material, identified within its feed by Key.
+type FeedEntry struct {
+	Key      string
+	Material NewMaterial
+}
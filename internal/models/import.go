@@ -0,0 +1,15 @@
+package models
+
+type ImportError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	DryRun   bool          `json:"dry_run"`
+	Section  string        `json:"section,omitempty"`
+	Rows     int           `json:"rows"`
+	Imported []string      `json:"imported"`
+	Errors   []ImportError `json:"errors"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// LinkCheck is the last check of a material's link. A check with an error or a 4xx/5xx status
+// is broken; RedirectTo is where the link ended up when it was redirected.
+type LinkCheck struct {
+	MaterialId string     `json:"material_id"`
+	Name       string     `json:"name"`
+	Link       string     `json:"link"`
+	StatusCode int        `json:"status_code"`
+	RedirectTo string     `json:"redirect_to,omitempty"`
+	LastError  string     `json:"last_error,omitempty"`
+	Failures   int        `json:"failures"`
+	CheckedAt  *time.Time `json:"checked_at"`
+}
+
+func (c LinkCheck) Broken() bool {
+	return c.LastError != "" || c.StatusCode >= 400
+}
+
+// CollectionHealth summarizes the link checks of a collection's materials.
+type CollectionHealth struct {
+	Links      int         `json:"links"`
+	Checked    int         `json:"checked"`
+	Broken     []LinkCheck `json:"broken"`
+	Redirected []LinkCheck `json:"redirected"`
+}
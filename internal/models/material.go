@@ -3,23 +3,34 @@ package models
 import "time"
 
 type NewMaterial struct {
-	CollectionID string `json:"collectionID"`
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	TypeId       string `json:"type_id"`
-	Xp           int    `json:"xp"`
-	Link         string `json:"link"`
+	CollectionID string   `json:"collectionID"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	TypeId       string   `json:"type_id"`
+	Xp           int      `json:"xp"`
+	Link         string   `json:"link"`
+	Tags         []string `json:"tags"`
+	ISBN         string   `json:"isbn,omitempty"`
 }
 
 type Material struct {
-	Id          string    `json:"id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	UserId      string    `json:"user_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	TypeId      string    `json:"type_id"`
-	Xp          int       `json:"xp"`
-	Link        string    `json:"link"`
-	Completed   bool      `json:"completed"`
+	Id          string          `json:"id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	UserId      string          `json:"user_id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	TypeId      string          `json:"type_id"`
+	Xp          int             `json:"xp"`
+	Link        string          `json:"link"`
+	Completed   bool            `json:"completed"`
+	Tags        []string        `json:"tags"`
+	Collections []CollectionRef `json:"collections,omitempty"`
+	Rank        float64         `json:"rank,omitempty"`
+	Highlight   string          `json:"highlight,omitempty"`
+}
+
+type MaterialFilter struct {
+	Tags []string
+	Page
 }
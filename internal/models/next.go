@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// NextCandidate is an incomplete material of a collection the user has joined, with the
+// collection's progress. Candidates of a collection come in the collection's order.
+type NextCandidate struct {
+	CollectionId   string
+	CollectionName string
+	CollectionXp   int
+	CompletedXp    int
+	LastActivity   *time.Time
+	MaterialId     string
+	Name           string
+	Link           string
+	TypeId         string
+	TypeName       string
+	TypeXp         int
+	Xp             int
+}
+
+// NextItem is a material in the user's "what to learn next" queue.
+type NextItem struct {
+	MaterialId       string  `json:"material_id"`
+	Name             string  `json:"name"`
+	Link             string  `json:"link"`
+	TypeId           string  `json:"type_id"`
+	Xp               int     `json:"xp"`
+	CollectionId     string  `json:"collection_id"`
+	CollectionName   string  `json:"collection_name"`
+	RemainingXp      int     `json:"remaining_xp"`
+	EstimatedMinutes int     `json:"estimated_minutes"`
+	Score            float64 `json:"score"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "slices"
+
+// Page selects one page of a list, an empty sort meaning creation order.
+type Page struct {
+	Sort   string
+	Limit  int
+	Cursor string
+}
+
+var (
+	CollectionSorts = []string{SortCreatedAt, SortUpdatedAt, SortName, SortXp, SortRating}
+	MaterialSorts   = []string{SortCreatedAt, SortUpdatedAt, SortName, SortXp}
+)
+
+// ValidSort reports whether sort is empty or one of sorts.
+func ValidSort(sort string, sorts []string) bool {
+	return sort == "" || slices.Contains(sorts, sort)
+}
+
+type CollectionList struct {
+	Collections []Collection `json:"collections"`
+	NextCursor  string       `json:"next_cursor,omitempty"`
+}
+
+type MaterialList struct {
+	Materials  []Material `json:"materials"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+type TypeMaterialList struct {
+	TypeMaterials []TypeMaterial `json:"type_materials"`
+	NextCursor    string         `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// Kinds of collection rankings.
+const (
+	RankingTrending = "trending"
+	RankingPopular  = "popular"
+)
+
+// RankedCollection is a public collection with the activity it was ranked by.
+type RankedCollection struct {
+	Collection
+	Score       float64 `json:"score"`
+	Joins       int     `json:"joins"`
+	Completions int     `json:"completions"`
+	XpEarned    int64   `json:"xp_earned"`
+}
+
+type RankedCollectionList struct {
+	Collections []RankedCollection `json:"collections"`
+	NextCursor  string             `json:"next_cursor,omitempty"`
+}
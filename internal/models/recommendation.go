@@ -0,0 +1,41 @@
+package models
+
+// Kinds of recommended items.
+const (
+	RecommendCollection = "collection"
+	RecommendMaterial   = "material"
+)
+
+// RecommendationItem is the text of a collection or material that recommendations are
+// computed from.
+type RecommendationItem struct {
+	Kind        string
+	Id          string
+	Name        string
+	Description string
+	Tags        []string
+}
+
+// RecommendationCorpus holds the materials the user has completed and the public collections
+// and materials that could be recommended to them.
+type RecommendationCorpus struct {
+	Completed   []RecommendationItem
+	Collections []RecommendationItem
+	Materials   []RecommendationItem
+}
+
+// Recommendation is an item similar to BecauseId, one of the user's completed materials.
+type Recommendation struct {
+	Kind        string  `json:"kind"`
+	Id          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Score       float64 `json:"score"`
+	BecauseId   string  `json:"because_id"`
+	Reason      string  `json:"reason"`
+}
+
+type Recommendations struct {
+	Collections []Recommendation `json:"collections"`
+	Materials   []Recommendation `json:"materials"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Engagement is how much of a joined collection the user has completed, from 0 to 1.
+type Engagement struct {
+	UserId       string
+	CollectionId string
+	Progress     float64
+}
+
+// CollectionSimilarity is a precomputed relation from one collection to another, based on the
+// users engaged with both.
+type CollectionSimilarity struct {
+	CollectionId string
+	RelatedId    string
+	Score        float64
+	CommonUsers  int
+}
+
+type RelatedCollection struct {
+	Id          string  `json:"id"`
+	Name        string  `json:"name"`
+	Score       float64 `json:"score"`
+	CommonUsers int     `json:"common_users"`
+}
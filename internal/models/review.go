@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type NewReview struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+type Review struct {
+	CollectionId string    `json:"collection_id"`
+	UserId       string    `json:"user_id"`
+	Login        string    `json:"login"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Rating       int       `json:"rating"`
+	Text         string    `json:"text"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type SavedSearch struct {
+	Id        string      `json:"id"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	UserId    string      `json:"user_id"`
+	Name      string      `json:"name"`
+	Query     SearchQuery `json:"query"`
+	CheckedAt time.Time   `json:"checked_at"`
+}
+
+type Notification struct {
+	Id            string         `json:"id"`
+	CreatedAt     time.Time      `json:"created_at"`
+	SavedSearchId sql.NullString `json:"saved_search_id"`
+	Kind          string         `json:"kind"`
+	ObjectId      string         `json:"object_id"`
+	Name          string         `json:"name"`
+	Read          bool           `json:"read"`
+}
@@ -0,0 +1,55 @@
+package models
+
+type SearchQuery struct {
+	Query     string   `json:"query"`
+	Tags      []string `json:"tags"`
+	TypeId    string   `json:"type_id,omitempty"`
+	MinXp     *int     `json:"min_xp,omitempty"`
+	MaxXp     *int     `json:"max_xp,omitempty"`
+	OwnerId   string   `json:"owner_id,omitempty"`
+	Completed *bool    `json:"completed,omitempty"`
+	Joined    *bool    `json:"joined,omitempty"`
+	Page      `json:"-"`
+}
+
+const (
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+	SortName      = "name"
+	SortXp        = "xp"
+)
+
+// ValidSearchSort reports whether sort is known to search, an empty sort meaning relevance.
+func ValidSearchSort(sort string) bool {
+	switch sort {
+	case "", SortCreatedAt, SortUpdatedAt, SortName, SortXp, SortRating:
+		return true
+	}
+	return false
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+type SearchFacets struct {
+	Types     []FacetCount `json:"types"`
+	Tags      []FacetCount `json:"tags"`
+	Owners    []FacetCount `json:"owners"`
+	Xp        []FacetCount `json:"xp"`
+	Completed []FacetCount `json:"completed"`
+	Joined    []FacetCount `json:"joined"`
+}
+
+type Suggestion struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Suggestions struct {
+	Titles []Suggestion `json:"titles"`
+	Tags   []string     `json:"tags"`
+}
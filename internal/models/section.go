@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Section groups materials of a collection in order, such as the lessons of an imported course.
+type Section struct {
+	Id        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	Materials []string  `json:"materials"`
+}
+
+// MaterialOrder lists material ids of a collection in the order they should be studied.
+type MaterialOrder struct {
+	Materials []string `json:"materials"`
+}
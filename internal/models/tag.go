@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Tag struct {
+	Id        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Name      string    `json:"name"`
+	Curated   bool      `json:"curated"`
+	Count     int       `json:"count"`
+}
+
+type TagList struct {
+	Tags []string `json:"tags"`
+}
@@ -0,0 +1,26 @@
+package pagination
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLimit(t *testing.T) {
+	assert.Equal(t, DefaultLimit, Limit(0))
+	assert.Equal(t, 5, Limit(5))
+	assert.Equal(t, MaxLimit, Limit(MaxLimit+1))
+}
+
+func TestEncodeDecode(t *testing.T) {
+	type position struct {
+		Offset int `json:"o"`
+	}
+
+	var decoded position
+	require.NoError(t, Decode(Encode(position{Offset: 40}), &decoded))
+	assert.Equal(t, 40, decoded.Offset)
+
+	assert.ErrorIs(t, Decode("not a cursor!", &decoded), ErrInvalidCursor)
+	assert.ErrorIs(t, Decode(Encode("string"), &decoded), ErrInvalidCursor)
+}
@@ -0,0 +1,388 @@
+package pdf
+
+import (
+	"embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+//go:embed fonts/DejaVuSans.ttf fonts/DejaVuSans-Bold.ttf
+var fonts embed.FS
+
+var ErrInvalidFont = errors.New("invalid TrueType font")
+
+// Font is a parsed TrueType font. It is read-only and shared between documents.
+type Font struct {
+	name       string
+	data       []byte
+	tables     map[string][]byte
+	unitsPerEm int
+	ascent     int
+	descent    int
+	bbox       [4]int
+	numGlyphs  int
+	advances   []uint16
+	cmap       func(r rune) uint16
+}
+
+var (
+	loadFonts        sync.Once
+	regular, bold    *Font
+	loadFontsFailure error
+)
+
+// DefaultFonts returns the embedded DejaVu Sans regular and bold faces, which cover Latin and Cyrillic.
+func DefaultFonts() (*Font, *Font, error) {
+	loadFonts.Do(func() {
+		load := func(name, file string) *Font {
+			data, err := fonts.ReadFile(file)
+			if err == nil {
+				var font *Font
+				if font, err = ParseFont(name, data); err == nil {
+					return font
+				}
+			}
+			loadFontsFailure = err
+			return nil
+		}
+		regular = load("DejaVuSans", "fonts/DejaVuSans.ttf")
+		bold = load("DejaVuSans-Bold", "fonts/DejaVuSans-Bold.ttf")
+	})
+	return regular, bold, loadFontsFailure
+}
+
+// ParseFont reads the tables of a TrueType font needed to lay out and embed text.
+func ParseFont(name string, data []byte) (*Font, error) {
+	tables, err := readTables(data)
+	if err != nil {
+		return nil, err
+	}
+	font := &Font{name: name, data: data, tables: tables}
+
+	head, hhea, maxp, hmtx := font.tables["head"], font.tables["hhea"], font.tables["maxp"], font.tables["hmtx"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 || font.tables["glyf"] == nil || font.tables["loca"] == nil {
+		return nil, ErrInvalidFont
+	}
+
+	font.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	for i := range font.bbox {
+		font.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+2*i:])))
+	}
+	font.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	font.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+	font.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+	if font.unitsPerEm == 0 {
+		return nil, ErrInvalidFont
+	}
+
+	numberOfHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if numberOfHMetrics == 0 || len(hmtx) < 4*numberOfHMetrics {
+		return nil, ErrInvalidFont
+	}
+	font.advances = make([]uint16, font.numGlyphs)
+	for gid := range font.advances {
+		font.advances[gid] = binary.BigEndian.Uint16(hmtx[4*min(gid, numberOfHMetrics-1):])
+	}
+
+	cmap, err := parseCmap(font.tables["cmap"])
+	if err != nil {
+		return nil, err
+	}
+	font.cmap = cmap
+
+	return font, nil
+}
+
+// readTables indexes the tables of an sfnt file by tag.
+func readTables(data []byte) (map[string][]byte, error) {
+	if len(data) < 12 {
+		return nil, ErrInvalidFont
+	}
+
+	tables := map[string][]byte{}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		record := 12 + 16*i
+		if record+16 > len(data) {
+			return nil, ErrInvalidFont
+		}
+		offset := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if offset+length > len(data) {
+			return nil, ErrInvalidFont
+		}
+		tables[string(data[record:record+4])] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+// Glyph returns the glyph of r, zero when the font lacks it.
+func (f *Font) Glyph(r rune) uint16 {
+	gid := f.cmap(r)
+	if int(gid) >= f.numGlyphs {
+		return 0
+	}
+	return gid
+}
+
+// Width returns the advance width of text set at size points.
+func (f *Font) Width(text string, size float64) float64 {
+	units := 0
+	for _, r := range text {
+		units += int(f.advances[f.Glyph(r)])
+	}
+	return float64(units) * size / float64(f.unitsPerEm)
+}
+
+// scale converts font units to the thousandths of an em used by PDF.
+func (f *Font) scale(units int) int {
+	return units * 1000 / f.unitsPerEm
+}
+
+// parseCmap returns a lookup over the Unicode subtable of a cmap table, preferring full
+// Unicode (format 12) over the Basic Multilingual Plane (format 4).
+func parseCmap(cmap []byte) (func(r rune) uint16, error) {
+	if len(cmap) < 4 {
+		return nil, ErrInvalidFont
+	}
+
+	var format4, format12 []byte
+	numTables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < numTables; i++ {
+		record := 4 + 8*i
+		if record+8 > len(cmap) {
+			return nil, ErrInvalidFont
+		}
+		platform := binary.BigEndian.Uint16(cmap[record:])
+		encoding := binary.BigEndian.Uint16(cmap[record+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[record+4:]))
+		if offset+4 > len(cmap) || !(platform == 0 || platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+		switch binary.BigEndian.Uint16(cmap[offset:]) {
+		case 4:
+			format4 = cmap[offset:]
+		case 12:
+			format12 = cmap[offset:]
+		}
+	}
+
+	switch {
+	case len(format12) >= 16:
+		groups := int(binary.BigEndian.Uint32(format12[12:]))
+		if 16+12*groups > len(format12) {
+			return nil, ErrInvalidFont
+		}
+		return func(r rune) uint16 {
+			// Groups are sorted by start code.
+			i := sort.Search(groups, func(i int) bool {
+				return rune(binary.BigEndian.Uint32(format12[16+12*i+4:])) >= r
+			})
+			if i == groups {
+				return 0
+			}
+			group := format12[16+12*i:]
+			start := rune(binary.BigEndian.Uint32(group))
+			if r < start {
+				return 0
+			}
+			return uint16(binary.BigEndian.Uint32(group[8:]) + uint32(r-start))
+		}, nil
+	case len(format4) >= 14:
+		segments := int(binary.BigEndian.Uint16(format4[6:])) / 2
+		if 16+8*segments > len(format4) {
+			return nil, ErrInvalidFont
+		}
+		ends, starts := format4[14:], format4[16+2*segments:]
+		deltas, ranges := format4[16+4*segments:], format4[16+6*segments:]
+		return func(r rune) uint16 {
+			if r > 0xFFFF {
+				return 0
+			}
+			c := uint16(r)
+			i := sort.Search(segments, func(i int) bool {
+				return binary.BigEndian.Uint16(ends[2*i:]) >= c
+			})
+			if i == segments || c < binary.BigEndian.Uint16(starts[2*i:]) {
+				return 0
+			}
+			delta := binary.BigEndian.Uint16(deltas[2*i:])
+			rangeOffset := int(binary.BigEndian.Uint16(ranges[2*i:]))
+			if rangeOffset == 0 {
+				return c + delta
+			}
+			at := 2*i + rangeOffset + 2*int(c-binary.BigEndian.Uint16(starts[2*i:]))
+			if at+2 > len(ranges) {
+				return 0
+			}
+			if gid := binary.BigEndian.Uint16(ranges[at:]); gid != 0 {
+				return gid + delta
+			}
+			return 0
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: no Unicode cmap", ErrInvalidFont)
+}
+
+// subsetTables are the tables a PDF viewer needs from an embedded TrueType font.
+var subsetTables = []string{"cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "prep"}
+
+// subset rebuilds the font keeping only the outlines of the used glyphs, and those their
+// composites refer to. Glyph ids stay unchanged, so text can be encoded with the original ids.
+func (f *Font) subset(used map[uint16]bool) ([]byte, error) {
+	head, loca, glyf := f.tables["head"], f.tables["loca"], f.tables["glyf"]
+	longLoca := binary.BigEndian.Uint16(head[50:]) == 1
+
+	glyph := func(gid uint16) ([]byte, error) {
+		g := int(gid)
+		var start, end int
+		if longLoca {
+			if 4*g+8 > len(loca) {
+				return nil, ErrInvalidFont
+			}
+			start, end = int(binary.BigEndian.Uint32(loca[4*g:])), int(binary.BigEndian.Uint32(loca[4*g+4:]))
+		} else {
+			if 2*g+4 > len(loca) {
+				return nil, ErrInvalidFont
+			}
+			start, end = 2*int(binary.BigEndian.Uint16(loca[2*g:])), 2*int(binary.BigEndian.Uint16(loca[2*g+2:]))
+		}
+		if start > end || end > len(glyf) {
+			return nil, ErrInvalidFont
+		}
+		return glyf[start:end], nil
+	}
+
+	keep := map[uint16]bool{}
+	pending := []uint16{0}
+	for gid := range used {
+		pending = append(pending, gid)
+	}
+	for len(pending) > 0 {
+		gid := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if keep[gid] || int(gid) >= f.numGlyphs {
+			continue
+		}
+		keep[gid] = true
+
+		data, err := glyph(gid)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+			continue
+		}
+		// A composite glyph lists its components until MORE_COMPONENTS is cleared.
+		for at := 10; at+4 <= len(data); {
+			flags := binary.BigEndian.Uint16(data[at:])
+			pending = append(pending, binary.BigEndian.Uint16(data[at+2:]))
+			at += 4
+			if flags&0x0001 != 0 {
+				at += 4
+			} else {
+				at += 2
+			}
+			switch {
+			case flags&0x0008 != 0:
+				at += 2
+			case flags&0x0040 != 0:
+				at += 4
+			case flags&0x0080 != 0:
+				at += 8
+			}
+			if flags&0x0020 == 0 {
+				break
+			}
+		}
+	}
+
+	newGlyf := []byte{}
+	newLoca := make([]byte, 4*(f.numGlyphs+1))
+	for gid := 0; gid < f.numGlyphs; gid++ {
+		binary.BigEndian.PutUint32(newLoca[4*gid:], uint32(len(newGlyf)))
+		if !keep[uint16(gid)] {
+			continue
+		}
+		data, err := glyph(uint16(gid))
+		if err != nil {
+			return nil, err
+		}
+		newGlyf = append(newGlyf, data...)
+		for len(newGlyf)%4 != 0 {
+			newGlyf = append(newGlyf, 0)
+		}
+	}
+	binary.BigEndian.PutUint32(newLoca[4*f.numGlyphs:], uint32(len(newGlyf)))
+
+	newHead := append([]byte{}, head...)
+	binary.BigEndian.PutUint32(newHead[8:], 0)
+	binary.BigEndian.PutUint16(newHead[50:], 1)
+
+	tables := map[string][]byte{}
+	for _, tag := range subsetTables {
+		if data, ok := f.tables[tag]; ok {
+			tables[tag] = data
+		}
+	}
+	tables["glyf"], tables["loca"], tables["head"] = newGlyf, newLoca, newHead
+
+	return writeFont(tables), nil
+}
+
+// writeFont assembles an sfnt file from tables, whose tags must already be sorted in subsetTables.
+func writeFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for _, tag := range subsetTables {
+		if _, ok := tables[tag]; ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= len(tags) {
+		entrySelector++
+	}
+	searchRange := 16 << entrySelector
+
+	out := make([]byte, 12+16*len(tags))
+	binary.BigEndian.PutUint32(out, 0x00010000)
+	binary.BigEndian.PutUint16(out[4:], uint16(len(tags)))
+	binary.BigEndian.PutUint16(out[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(16*len(tags)-searchRange))
+
+	headOffset := 0
+	for i, tag := range tags {
+		data := tables[tag]
+		record := 12 + 16*i
+		copy(out[record:], tag)
+		binary.BigEndian.PutUint32(out[record+4:], checksum(data))
+		binary.BigEndian.PutUint32(out[record+8:], uint32(len(out)))
+		binary.BigEndian.PutUint32(out[record+12:], uint32(len(data)))
+		if tag == "head" {
+			headOffset = len(out)
+		}
+		out = append(out, data...)
+		for len(out)%4 != 0 {
+			out = append(out, 0)
+		}
+	}
+
+	binary.BigEndian.PutUint32(out[headOffset+8:], 0xB1B0AFBA-checksum(out))
+	return out
+}
+
+func checksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
@@ -0,0 +1,361 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// A4 in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+	Margin     = 50.0
+)
+
+const lineSpacing = 1.35
+
+type Style struct {
+	Size   float64
+	Bold   bool
+	Gray   bool
+	Indent float64
+}
+
+// Document lays out text top to bottom on A4 pages, breaking lines and pages as needed.
+type Document struct {
+	title         string
+	regular, bold *Font
+	used          map[*Font]map[uint16]rune
+	pages         []*bytes.Buffer
+	y             float64
+}
+
+func New(title string) (*Document, error) {
+	regular, bold, err := DefaultFonts()
+	if err != nil {
+		return nil, err
+	}
+	return &Document{
+		title:   title,
+		regular: regular,
+		bold:    bold,
+		used:    map[*Font]map[uint16]rune{},
+	}, nil
+}
+
+// Paragraph writes text wrapped to the page width. Newlines start new lines.
+func (d *Document) Paragraph(text string, style Style) {
+	d.Columns(text, "", style)
+}
+
+// Columns writes left wrapped to the space remaining beside right, which is
+// right-aligned on the first line.
+func (d *Document) Columns(left, right string, style Style) {
+	font := d.font(style)
+	rightWidth := 0.0
+	if right != "" {
+		rightWidth = font.Width(right, style.Size) + style.Size
+	}
+
+	x := Margin + style.Indent
+	for i, line := range wrap(font, left, style.Size, PageWidth-Margin-x-rightWidth) {
+		d.advance(style.Size * lineSpacing)
+		d.text(font, style, x, line)
+		if i == 0 && right != "" {
+			d.text(font, style, PageWidth-Margin-font.Width(right, style.Size), right)
+		}
+	}
+}
+
+// Space leaves vertical room of height points.
+func (d *Document) Space(height float64) {
+	if len(d.pages) > 0 && d.y-height > Margin {
+		d.y -= height
+	}
+}
+
+// ProgressBar draws a bar filled to fraction, clamped to [0, 1].
+func (d *Document) ProgressBar(fraction float64, indent float64) {
+	const height = 6.0
+	fraction = max(0, min(1, fraction))
+
+	d.advance(height * 2)
+	x, width := Margin+indent, PageWidth-2*Margin-indent
+	fmt.Fprintf(d.page(), "0.85 g %.2f %.2f %.2f %.2f re f\n", x, d.y, width, height)
+	if fraction > 0 {
+		fmt.Fprintf(d.page(), "0.2 0.55 0.3 rg %.2f %.2f %.2f %.2f re f\n", x, d.y, width*fraction, height)
+	}
+	d.page().WriteString("0 g\n")
+}
+
+// Rule draws a horizontal line across the page.
+func (d *Document) Rule() {
+	d.advance(8)
+	fmt.Fprintf(d.page(), "0.7 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n", Margin, d.y+4, PageWidth-Margin, d.y+4)
+}
+
+func (d *Document) font(style Style) *Font {
+	if style.Bold {
+		return d.bold
+	}
+	return d.regular
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.newPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = PageHeight - Margin
+}
+
+// advance moves down by height, starting a new page when the bottom margin is reached.
+func (d *Document) advance(height float64) {
+	if len(d.pages) == 0 || d.y-height < Margin {
+		d.newPage()
+	}
+	d.y -= height
+}
+
+func (d *Document) text(font *Font, style Style, x float64, text string) {
+	d.show(d.page(), font, style, x, d.y, text)
+}
+
+// show draws text on page with its baseline starting at x, y.
+func (d *Document) show(page *bytes.Buffer, font *Font, style Style, x, y float64, text string) {
+	used := d.used[font]
+	if used == nil {
+		used = map[uint16]rune{}
+		d.used[font] = used
+	}
+
+	var glyphs strings.Builder
+	for _, r := range text {
+		gid := font.Glyph(r)
+		used[gid] = r
+		fmt.Fprintf(&glyphs, "%04X", gid)
+	}
+
+	name := "F1"
+	if font == d.bold {
+		name = "F2"
+	}
+	gray := 0.0
+	if style.Gray {
+		gray = 0.4
+	}
+	fmt.Fprintf(page, "BT %.2f g /%s %.2f Tf %.2f %.2f Td <%s> Tj ET\n", gray, name, style.Size, x, y, glyphs.String())
+}
+
+// wrap breaks text into lines no wider than width, splitting words that do not fit on a line of their own.
+func wrap(font *Font, text string, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if font.Width(candidate, size) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			for font.Width(word, size) > width {
+				n := fit(font, word, size, width)
+				lines = append(lines, word[:n])
+				word = word[n:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fit returns the byte length of the longest prefix of word that fits width, at least one rune.
+func fit(font *Font, word string, size, width float64) int {
+	n := 0
+	for i, r := range word {
+		if i > 0 && font.Width(word[:i+utf8.RuneLen(r)], size) > width {
+			break
+		}
+		n = i + utf8.RuneLen(r)
+	}
+	return n
+}
+
+// WriteTo renders the document, numbering pages in the footer.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.newPage()
+	}
+	footer := Style{Size: 8, Gray: true}
+	for i, page := range d.pages {
+		d.show(page, d.regular, footer, Margin, Margin/2, fmt.Sprintf("%s · %d / %d", d.title, i+1, len(d.pages)))
+	}
+
+	out := &objectWriter{}
+	out.buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+
+	fonts := []*Font{d.regular, d.bold}
+	// Objects 1-3 are the catalog, page tree and info, then five per font, then two per page.
+	fontObject := func(i int) int { return 4 + 5*i }
+	pageObject := func(i int) int { return 4 + 5*len(fonts) + 2*i }
+
+	out.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageObject(i))
+	}
+	out.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	out.object(3, fmt.Sprintf("<< /Title %s /Producer (skillBuilder) >>", textString(d.title)))
+
+	resources := []string{}
+	for i, font := range fonts {
+		if err := d.writeFont(out, font, fontObject(i)); err != nil {
+			return 0, err
+		}
+		resources = append(resources, fmt.Sprintf("/F%d %d 0 R", i+1, fontObject(i)))
+	}
+
+	for i, page := range d.pages {
+		out.object(pageObject(i), fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, strings.Join(resources, " "), pageObject(i)+1))
+		if err := out.stream(pageObject(i)+1, "", page.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+
+	out.trailer()
+	return out.buf.WriteTo(w)
+}
+
+// writeFont embeds a subset of font as a composite font whose character codes are glyph ids.
+func (d *Document) writeFont(out *objectWriter, font *Font, n int) error {
+	used := d.used[font]
+	gids := make([]int, 0, len(used))
+	subset := make(map[uint16]bool, len(used))
+	for gid := range used {
+		gids = append(gids, int(gid))
+		subset[gid] = true
+	}
+	sort.Ints(gids)
+
+	data, err := font.subset(subset)
+	if err != nil {
+		return err
+	}
+
+	// A subset font name carries a six letter tag.
+	name := "SKLBAA+" + font.name
+	if font == d.bold {
+		name = "SKLBAB+" + font.name
+	}
+
+	widths := make([]string, len(gids))
+	for i, gid := range gids {
+		widths[i] = fmt.Sprintf("%d [%d]", gid, font.scale(int(font.advances[gid])))
+	}
+
+	out.object(n, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		name, n+1, n+4))
+	out.object(n+1, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /W [%s] /CIDToGIDMap /Identity >>",
+		name, n+2, strings.Join(widths, " ")))
+	out.object(n+2, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		name, font.scale(font.bbox[0]), font.scale(font.bbox[1]), font.scale(font.bbox[2]), font.scale(font.bbox[3]),
+		font.scale(font.ascent), font.scale(font.descent), font.scale(font.ascent), n+3))
+	if err := out.stream(n+3, fmt.Sprintf("/Length1 %d", len(data)), data); err != nil {
+		return err
+	}
+
+	var cmap strings.Builder
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for start := 0; start < len(gids); start += 100 {
+		end := min(start+100, len(gids))
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", end-start)
+		for _, gid := range gids[start:end] {
+			fmt.Fprintf(&cmap, "<%04X> <", gid)
+			for _, unit := range utf16.Encode([]rune{used[uint16(gid)]}) {
+				fmt.Fprintf(&cmap, "%04X", unit)
+			}
+			cmap.WriteString(">\n")
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+
+	return out.stream(n+4, "", []byte(cmap.String()))
+}
+
+// textString encodes text as a UTF-16 PDF string.
+func textString(text string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(text)) {
+		fmt.Fprintf(&b, "%04X", unit)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// objectWriter writes numbered objects and remembers their offsets for the cross-reference table.
+type objectWriter struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+}
+
+func (w *objectWriter) object(n int, body string) {
+	if w.offsets == nil {
+		w.offsets = map[int]int{}
+	}
+	w.offsets[n] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", n, body)
+}
+
+// stream writes data compressed, dict holding any entries besides the filter and length.
+func (w *objectWriter) stream(n int, dict string, data []byte) error {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	w.object(n, fmt.Sprintf("<< /Filter /FlateDecode /Length %d %s>>\nstream\n%s\nendstream", compressed.Len(), dict, compressed.Bytes()))
+	return nil
+}
+
+func (w *objectWriter) trailer() {
+	size := 0
+	for n := range w.offsets {
+		size = max(size, n)
+	}
+	size++
+
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for n := 1; n < size; n++ {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", w.offsets[n])
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, xref)
+}
@@ -0,0 +1,112 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDefaultFonts(t *testing.T) {
+	regular, bold, err := DefaultFonts()
+	require.NoError(t, err)
+
+	for _, font := range []*Font{regular, bold} {
+		for _, r := range "AЖ☑☐✓" {
+			assert.NotZero(t, font.Glyph(r), string(r))
+		}
+		assert.Zero(t, font.Glyph(0x10FFFF))
+		assert.Greater(t, font.Width("Привет", 12), font.Width("При", 12))
+	}
+}
+
+func TestSubset(t *testing.T) {
+	regular, _, err := DefaultFonts()
+	require.NoError(t, err)
+
+	used := map[uint16]bool{regular.Glyph('Й'): true, regular.Glyph('a'): true}
+	data, err := regular.subset(used)
+	require.NoError(t, err)
+	assert.Less(t, len(data), len(regular.data)/10)
+	assert.Equal(t, uint32(0xB1B0AFBA), checksum(data))
+
+	tables, err := readTables(data)
+	require.NoError(t, err)
+	assert.Equal(t, regular.tables["hmtx"], tables["hmtx"])
+	assert.NotContains(t, tables, "cmap")
+
+	// Outlines are kept for used glyphs only, under their original ids.
+	loca := tables["loca"]
+	length := func(gid uint16) uint32 {
+		return binary.BigEndian.Uint32(loca[4*int(gid)+4:]) - binary.BigEndian.Uint32(loca[4*int(gid):])
+	}
+	assert.NotZero(t, length(regular.Glyph('Й')))
+	assert.NotZero(t, length(regular.Glyph('a')))
+	assert.Zero(t, length(regular.Glyph('Z')))
+}
+
+func TestDocument(t *testing.T) {
+	doc, err := New("Учебный план")
+	require.NoError(t, err)
+
+	doc.Paragraph("Учебный план", Style{Size: 18, Bold: true})
+	for i := 0; i < 60; i++ {
+		doc.Columns(fmt.Sprintf("☑ Материал %d with a rather long title that has to wrap onto the next line of the page", i), "10 XP", Style{Size: 11})
+	}
+	doc.ProgressBar(0.5, 0)
+	doc.Rule()
+
+	var buf bytes.Buffer
+	_, err = doc.WriteTo(&buf)
+	require.NoError(t, err)
+	data := buf.Bytes()
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.7\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Regexp(t, `/Count [2-9] `, string(data))
+
+	// Every cross-reference entry points at its object.
+	xref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(data)
+	require.NotNil(t, xref)
+	start, _ := strconv.Atoi(string(xref[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(data[start:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+
+	// Text maps back to Unicode for copying and search.
+	streams := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(data, -1)
+	var cmaps string
+	for _, stream := range streams {
+		r, err := zlib.NewReader(bytes.NewReader(stream[1]))
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		if bytes.Contains(content, []byte("beginbfchar")) {
+			cmaps += string(content)
+		}
+	}
+	assert.Contains(t, cmaps, "<0423>")
+	assert.Contains(t, cmaps, "<2611>")
+}
+
+func TestWrap(t *testing.T) {
+	regular, _, err := DefaultFonts()
+	require.NoError(t, err)
+
+	lines := wrap(regular, "one two three\nfour", 10, regular.Width("one two", 10))
+	assert.Equal(t, []string{"one two", "three", "four"}, lines)
+
+	lines = wrap(regular, "abcdefghij", 10, regular.Width("abc", 10))
+	assert.Equal(t, "abc", lines[0])
+	assert.Equal(t, "abcdefghij", strings.Join(lines, ""))
+}
@@ -0,0 +1,107 @@
+package planner
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"math"
+	"strings"
+	"time"
+)
+
+// Minutes one unit of a seeded material type takes, its unit being the type's characteristic.
+var minutesPerUnit = map[string]float64{
+	"книга":       2,  // страница
+	"аудио-книга": 60, // час
+	"статья":      15, // штука
+	"курс":        30, // урок
+	"видеоролик":  60, // час
+}
+
+// minutesPerXp estimates materials of other types.
+const minutesPerXp = 5
+
+// activityHalfLife is how long after the last completion a collection loses half its recency.
+const activityHalfLife = 7 * 24 * time.Hour
+
+// Weights of the parts of a material's score.
+const (
+	recencyWeight  = 0.4
+	progressWeight = 0.35
+	effortWeight   = 0.25
+)
+
+// Plan orders the candidates into a queue of at most limit materials. Each collection's
+// materials keep their order, earlier ones being prerequisites of later ones, and the
+// collections' next materials compete on how recently the user worked on the collection, how
+// much of it is done and how quickly the material can be finished.
+func Plan(candidates []models.NextCandidate, now time.Time, limit int) []models.NextItem {
+	var order []string
+	queues := map[string][]models.NextCandidate{}
+	for _, candidate := range candidates {
+		if _, ok := queues[candidate.CollectionId]; !ok {
+			order = append(order, candidate.CollectionId)
+		}
+		queues[candidate.CollectionId] = append(queues[candidate.CollectionId], candidate)
+	}
+
+	items := []models.NextItem{}
+	for len(items) < limit {
+		var best *models.NextItem
+		bestCollection := ""
+		for _, id := range order {
+			queue := queues[id]
+			if len(queue) == 0 {
+				continue
+			}
+			item := score(queue[0], now)
+			if best == nil || item.Score > best.Score ||
+				item.Score == best.Score && strings.ToLower(item.CollectionName) < strings.ToLower(best.CollectionName) {
+				best, bestCollection = &item, id
+			}
+		}
+		if best == nil {
+			break
+		}
+		items = append(items, *best)
+		queues[bestCollection] = queues[bestCollection][1:]
+	}
+	return items
+}
+
+func score(candidate models.NextCandidate, now time.Time) models.NextItem {
+	recency := 0.0
+	if candidate.LastActivity != nil {
+		recency = math.Pow(0.5, float64(now.Sub(*candidate.LastActivity))/float64(activityHalfLife))
+		recency = min(recency, 1)
+	}
+
+	progress := 0.0
+	if candidate.CollectionXp > 0 {
+		progress = float64(candidate.CompletedXp) / float64(candidate.CollectionXp)
+	}
+
+	minutes := EstimateMinutes(candidate)
+	effort := 1 / (1 + minutes/60)
+
+	return models.NextItem{
+		MaterialId:       candidate.MaterialId,
+		Name:             candidate.Name,
+		Link:             candidate.Link,
+		TypeId:           candidate.TypeId,
+		Xp:               candidate.Xp,
+		CollectionId:     candidate.CollectionId,
+		CollectionName:   candidate.CollectionName,
+		RemainingXp:      candidate.CollectionXp - candidate.CompletedXp,
+		EstimatedMinutes: int(math.Ceil(minutes)),
+		Score:            math.Round((recencyWeight*recency+progressWeight*progress+effortWeight*effort)*1000) / 1000,
+	}
+}
+
+// EstimateMinutes estimates how long the material takes from its XP, which is its number of
+// units times the XP of a unit of its type.
+func EstimateMinutes(candidate models.NextCandidate) float64 {
+	perUnit, ok := minutesPerUnit[strings.ToLower(candidate.TypeName)]
+	if !ok || candidate.TypeXp <= 0 {
+		return float64(candidate.Xp) * minutesPerXp
+	}
+	return float64(candidate.Xp) / float64(candidate.TypeXp) * perUnit
+}
@@ -0,0 +1,52 @@
+package planner
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPlan(t *testing.T) {
+	now := time.Date(2024, 10, 19, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	longAgo := now.Add(-90 * 24 * time.Hour)
+
+	active := models.NextCandidate{CollectionId: "go", CollectionName: "Go", CollectionXp: 100, CompletedXp: 60, LastActivity: &yesterday}
+	stale := models.NextCandidate{CollectionId: "sql", CollectionName: "SQL", CollectionXp: 100, CompletedXp: 10, LastActivity: &longAgo}
+
+	candidates := []models.NextCandidate{
+		with(active, "go1", "статья", 3, 3),
+		with(active, "go2", "книга", 1, 300),
+		with(stale, "sql1", "видеоролик", 10, 10),
+	}
+
+	items := Plan(candidates, now, 10)
+
+	require.Len(t, items, 3)
+	assert.Equal(t, "go1", items[0].MaterialId)
+	assert.Equal(t, 15, items[0].EstimatedMinutes)
+	assert.Equal(t, 40, items[0].RemainingXp)
+	// go2 stays after go1 even though a shorter material of another collection waits.
+	assert.Equal(t, "go2", items[1].MaterialId)
+	assert.Equal(t, 600, items[1].EstimatedMinutes)
+	assert.Equal(t, "sql1", items[2].MaterialId)
+
+	assert.Len(t, Plan(candidates, now, 1), 1)
+	assert.Empty(t, Plan(nil, now, 10))
+}
+
+func TestEstimateMinutes(t *testing.T) {
+	assert.Equal(t, 90.0, EstimateMinutes(models.NextCandidate{TypeName: "курс", TypeXp: 10, Xp: 30}))
+	assert.Equal(t, 50.0, EstimateMinutes(models.NextCandidate{TypeName: "подкаст", TypeXp: 2, Xp: 10}))
+}
+
+func with(collection models.NextCandidate, id, typeName string, typeXp, xp int) models.NextCandidate {
+	collection.MaterialId = id
+	collection.Name = id
+	collection.TypeName = typeName
+	collection.TypeXp = typeXp
+	collection.Xp = xp
+	return collection
+}
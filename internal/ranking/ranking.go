@@ -0,0 +1,67 @@
+package ranking
+
+import (
+	"context"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"log"
+	"time"
+)
+
+type Repositories interface {
+	RefreshCollectionRanking(kind string, since time.Time) (int, error)
+}
+
+// Worker periodically recomputes the trending and popular rankings of public collections.
+type Worker struct {
+	repos    Repositories
+	interval time.Duration
+	windows  map[string]time.Duration
+}
+
+// New returns a worker ranking trending collections by their activity over trendingWindow and
+// popular ones over popularWindow, zero meaning all time.
+func New(repos Repositories, interval, trendingWindow, popularWindow time.Duration) *Worker {
+	return &Worker{
+		repos:    repos,
+		interval: interval,
+		windows: map[string]time.Duration{
+			models.RankingTrending: trendingWindow,
+			models.RankingPopular:  popularWindow,
+		},
+	}
+}
+
+// Run refreshes the rankings right away, so they aren't empty after a deploy, and then every
+// interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Refresh(time.Now()); err != nil {
+			log.Println("collection rankings:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh recomputes every ranking as of now. A failing ranking doesn't stop the others, the
+// last error is returned.
+func (w *Worker) Refresh(now time.Time) error {
+	var lastErr error
+	for _, kind := range []string{models.RankingTrending, models.RankingPopular} {
+		var since time.Time
+		if window := w.windows[kind]; window > 0 {
+			since = now.Add(-window)
+		}
+		if _, err := w.repos.RefreshCollectionRanking(kind, since); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
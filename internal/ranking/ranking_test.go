@@ -0,0 +1,32 @@
+package ranking
+
+import (
+	"errors"
+	"github.com/grafchitaru/skillBuilder/internal/mocks"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRefresh(t *testing.T) {
+	now := time.Date(2024, 10, 19, 12, 0, 0, 0, time.UTC)
+	refreshed := map[string]time.Time{}
+	mockStorage := &mocks.MockStorage{
+		RefreshCollectionRankingFunc: func(kind string, since time.Time) (int, error) {
+			refreshed[kind] = since
+			if kind == models.RankingTrending {
+				return 0, errors.New("refresh error")
+			}
+			return 3, nil
+		},
+	}
+
+	err := New(mockStorage, time.Hour, 7*24*time.Hour, 0).Refresh(now)
+
+	assert.Error(t, err)
+	assert.Equal(t, map[string]time.Time{
+		models.RankingTrending: now.Add(-7 * 24 * time.Hour),
+		models.RankingPopular:  {},
+	}, refreshed)
+}
@@ -0,0 +1,172 @@
+package recommend
+
+import (
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Weights of a term by where it appears, relative to a word of the description.
+const (
+	nameWeight = 2
+	tagWeight  = 3
+)
+
+// Words too common to tell items apart.
+var stopWords = map[string]bool{
+	"an": true, "as": true, "at": true, "be": true, "by": true, "in": true, "is": true, "it": true,
+	"of": true, "on": true, "or": true, "to": true, "we": true,
+	"во": true, "да": true, "до": true, "за": true, "из": true, "на": true, "не": true, "но": true,
+	"об": true, "от": true, "по": true, "со": true, "то": true,
+	"and": true, "are": true, "for": true, "from": true, "how": true, "into": true, "the": true,
+	"this": true, "that": true, "with": true, "you": true, "your": true, "what": true, "about": true,
+	"для": true, "как": true, "что": true, "это": true, "или": true, "при": true, "без": true,
+	"над": true, "под": true, "его": true, "она": true, "они": true, "все": true, "так": true,
+}
+
+type vector map[string]float64
+
+// Recommend ranks candidates by the TF-IDF cosine similarity of their name, description and
+// tags to those of the completed items. It returns up to limit candidates similar to at least
+// one completed item, each explained by the most similar one.
+func Recommend(completed, candidates []models.RecommendationItem, limit int) []models.Recommendation {
+	if len(completed) == 0 || len(candidates) == 0 || limit <= 0 {
+		return []models.Recommendation{}
+	}
+
+	all := make([][]string, 0, len(completed)+len(candidates))
+	for _, item := range completed {
+		all = append(all, terms(item))
+	}
+	for _, item := range candidates {
+		all = append(all, terms(item))
+	}
+	idf := inverseFrequencies(all)
+
+	completedVectors := make([]vector, len(completed))
+	for i := range completed {
+		completedVectors[i] = weigh(all[i], idf)
+	}
+
+	recommendations := []models.Recommendation{}
+	for i, item := range candidates {
+		candidate := weigh(all[len(completed)+i], idf)
+
+		best, because := 0.0, -1
+		for j, done := range completedVectors {
+			if score := cosine(candidate, done); score > best {
+				best, because = score, j
+			}
+		}
+		if because < 0 {
+			continue
+		}
+
+		recommendations = append(recommendations, models.Recommendation{
+			Kind:        item.Kind,
+			Id:          item.Id,
+			Name:        item.Name,
+			Description: item.Description,
+			Score:       math.Round(best*1000) / 1000,
+			BecauseId:   completed[because].Id,
+			Reason:      fmt.Sprintf("Because you completed %q", completed[because].Name),
+		})
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Name < recommendations[j].Name
+	})
+	return recommendations[:min(len(recommendations), limit)]
+}
+
+// terms lists the item's words, repeated by weight, and its tags marked with a #.
+func terms(item models.RecommendationItem) []string {
+	var result []string
+	for _, word := range words(item.Name) {
+		for range nameWeight {
+			result = append(result, word)
+		}
+	}
+	result = append(result, words(item.Description)...)
+	for _, tag := range item.Tags {
+		for range tagWeight {
+			result = append(result, "#"+strings.ToLower(tag))
+		}
+	}
+	return result
+}
+
+// words splits text into lowercase words of at least two characters, skipping stop words.
+// Pluses and hashes are kept so that names like C++ and C# stay words.
+func words(text string) []string {
+	var result []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}) {
+		if utf8.RuneCountInString(word) < 2 || stopWords[word] {
+			continue
+		}
+		result = append(result, word)
+	}
+	return result
+}
+
+// inverseFrequencies weighs each term by how rare it is among the documents, with smoothing
+// so that a term found in every document still counts a little.
+func inverseFrequencies(documents [][]string) map[string]float64 {
+	frequency := map[string]int{}
+	for _, document := range documents {
+		seen := map[string]bool{}
+		for _, term := range document {
+			if !seen[term] {
+				seen[term] = true
+				frequency[term]++
+			}
+		}
+	}
+
+	idf := make(map[string]float64, len(frequency))
+	for term, n := range frequency {
+		idf[term] = math.Log(float64(1+len(documents))/float64(1+n)) + 1
+	}
+	return idf
+}
+
+// weigh turns a document's terms into a unit vector of sublinear term frequency times idf.
+func weigh(document []string, idf map[string]float64) vector {
+	counts := map[string]int{}
+	for _, term := range document {
+		counts[term]++
+	}
+
+	v := make(vector, len(counts))
+	norm := 0.0
+	for term, n := range counts {
+		weight := (1 + math.Log(float64(n))) * idf[term]
+		v[term] = weight
+		norm += weight * weight
+	}
+	norm = math.Sqrt(norm)
+	for term := range v {
+		v[term] /= norm
+	}
+	return v
+}
+
+func cosine(a, b vector) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	sum := 0.0
+	for term, weight := range a {
+		sum += weight * b[term]
+	}
+	return sum
+}
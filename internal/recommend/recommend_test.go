@@ -0,0 +1,38 @@
+package recommend
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRecommend(t *testing.T) {
+	completed := []models.RecommendationItem{
+		{Kind: models.RecommendMaterial, Id: "m1", Name: "Concurrency in Go", Description: "Goroutines and channels", Tags: []string{"golang"}},
+		{Kind: models.RecommendMaterial, Id: "m2", Name: "PostgreSQL indexes", Description: "B-tree and GIN indexes explained"},
+	}
+	candidates := []models.RecommendationItem{
+		{Kind: models.RecommendCollection, Id: "c1", Name: "Cooking basics", Description: "Knife skills"},
+		{Kind: models.RecommendCollection, Id: "c2", Name: "Go channels in depth", Description: "Patterns with goroutines", Tags: []string{"golang"}},
+		{Kind: models.RecommendCollection, Id: "c3", Name: "Query tuning", Description: "Choosing PostgreSQL indexes"},
+	}
+
+	recommendations := Recommend(completed, candidates, 10)
+
+	require.Len(t, recommendations, 2)
+	assert.Equal(t, "c2", recommendations[0].Id)
+	assert.Equal(t, models.RecommendCollection, recommendations[0].Kind)
+	assert.Equal(t, "m1", recommendations[0].BecauseId)
+	assert.Equal(t, `Because you completed "Concurrency in Go"`, recommendations[0].Reason)
+	assert.Equal(t, "c3", recommendations[1].Id)
+	assert.Equal(t, "m2", recommendations[1].BecauseId)
+	assert.Greater(t, recommendations[0].Score, recommendations[1].Score)
+
+	assert.Len(t, Recommend(completed, candidates, 1), 1)
+	assert.Empty(t, Recommend(nil, candidates, 10))
+}
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []string{"c++", "go", "программирование"}, words("C++ and Go: программирование на"))
+}
@@ -0,0 +1,143 @@
+package related
+
+import (
+	"context"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"log"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// PerCollection is how many related collections are kept for each collection.
+	PerCollection = 10
+	// MinCommonUsers is how many users two collections need in common to be related, so that
+	// a single user's path doesn't link them.
+	MinCommonUsers = 2
+	// MaxUserCollections caps how many collections of one user are paired, as pairs grow with
+	// its square.
+	MaxUserCollections = 200
+)
+
+type Repositories interface {
+	GetCollectionEngagement() ([]models.Engagement, error)
+	ReplaceRelatedCollections(similarities []models.CollectionSimilarity) error
+}
+
+// Worker periodically recomputes related collections from what users have completed.
+type Worker struct {
+	repos    Repositories
+	interval time.Duration
+}
+
+func New(repos Repositories, interval time.Duration) *Worker {
+	return &Worker{repos: repos, interval: interval}
+}
+
+// Run refreshes related collections every interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.Refresh(); err != nil {
+				log.Println("related collections:", err)
+			}
+		}
+	}
+}
+
+// Refresh recomputes and stores related collections, returning how many relations were stored.
+func (w *Worker) Refresh() (int, error) {
+	engagement, err := w.repos.GetCollectionEngagement()
+	if err != nil {
+		return 0, err
+	}
+
+	similarities := Compute(engagement)
+	if err := w.repos.ReplaceRelatedCollections(similarities); err != nil {
+		return 0, err
+	}
+	return len(similarities), nil
+}
+
+type pair struct {
+	a, b string
+}
+
+// Compute relates collections by the cosine similarity of their users' progress: two
+// collections are close when the same users completed much of both. It keeps the
+// PerCollection closest collections of each, among those with MinCommonUsers users in common.
+func Compute(engagement []models.Engagement) []models.CollectionSimilarity {
+	byUser := map[string][]models.Engagement{}
+	norms := map[string]float64{}
+	for _, e := range engagement {
+		byUser[e.UserId] = append(byUser[e.UserId], e)
+		norms[e.CollectionId] += e.Progress * e.Progress
+	}
+
+	dots := map[pair]float64{}
+	common := map[pair]int{}
+	for _, collections := range byUser {
+		// The most completed collections say the most about the user.
+		sort.Slice(collections, func(i, j int) bool {
+			if collections[i].Progress != collections[j].Progress {
+				return collections[i].Progress > collections[j].Progress
+			}
+			return collections[i].CollectionId < collections[j].CollectionId
+		})
+		collections = collections[:min(len(collections), MaxUserCollections)]
+
+		for i, a := range collections {
+			for _, b := range collections[i+1:] {
+				key := pair{a.CollectionId, b.CollectionId}
+				if key.a > key.b {
+					key.a, key.b = key.b, key.a
+				}
+				dots[key] += a.Progress * b.Progress
+				common[key]++
+			}
+		}
+	}
+
+	byCollection := map[string][]models.CollectionSimilarity{}
+	for key, dot := range dots {
+		if common[key] < MinCommonUsers {
+			continue
+		}
+		score := math.Round(dot/math.Sqrt(norms[key.a]*norms[key.b])*1000) / 1000
+		byCollection[key.a] = append(byCollection[key.a], models.CollectionSimilarity{
+			CollectionId: key.a, RelatedId: key.b, Score: score, CommonUsers: common[key],
+		})
+		byCollection[key.b] = append(byCollection[key.b], models.CollectionSimilarity{
+			CollectionId: key.b, RelatedId: key.a, Score: score, CommonUsers: common[key],
+		})
+	}
+
+	ids := make([]string, 0, len(byCollection))
+	for id := range byCollection {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	similarities := []models.CollectionSimilarity{}
+	for _, id := range ids {
+		related := byCollection[id]
+		sort.Slice(related, func(i, j int) bool {
+			if related[i].Score != related[j].Score {
+				return related[i].Score > related[j].Score
+			}
+			if related[i].CommonUsers != related[j].CommonUsers {
+				return related[i].CommonUsers > related[j].CommonUsers
+			}
+			return related[i].RelatedId < related[j].RelatedId
+		})
+		similarities = append(similarities, related[:min(len(related), PerCollection)]...)
+	}
+	return similarities
+}
@@ -0,0 +1,70 @@
+package related
+
+import (
+	"errors"
+	"github.com/grafchitaru/skillBuilder/internal/mocks"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCompute(t *testing.T) {
+	engagement := []models.Engagement{
+		{UserId: "u1", CollectionId: "go", Progress: 1},
+		{UserId: "u1", CollectionId: "sql", Progress: 1},
+		{UserId: "u1", CollectionId: "cooking", Progress: 0.5},
+		{UserId: "u2", CollectionId: "go", Progress: 1},
+		{UserId: "u2", CollectionId: "sql", Progress: 0.5},
+		{UserId: "u3", CollectionId: "go", Progress: 0.5},
+		{UserId: "u3", CollectionId: "cooking", Progress: 1},
+		{UserId: "u4", CollectionId: "sql", Progress: 1},
+	}
+
+	similarities := Compute(engagement)
+
+	// cooking shares one user with sql, which is too few.
+	assert.Equal(t, []models.CollectionSimilarity{
+		{CollectionId: "cooking", RelatedId: "go", Score: 0.596, CommonUsers: 2},
+		{CollectionId: "go", RelatedId: "sql", Score: 0.667, CommonUsers: 2},
+		{CollectionId: "go", RelatedId: "cooking", Score: 0.596, CommonUsers: 2},
+		{CollectionId: "sql", RelatedId: "go", Score: 0.667, CommonUsers: 2},
+	}, similarities)
+}
+
+func TestRefresh(t *testing.T) {
+	var stored []models.CollectionSimilarity
+	mockStorage := &mocks.MockStorage{
+		GetCollectionEngagementFunc: func() ([]models.Engagement, error) {
+			return []models.Engagement{
+				{UserId: "u1", CollectionId: "a", Progress: 1},
+				{UserId: "u1", CollectionId: "b", Progress: 1},
+				{UserId: "u2", CollectionId: "a", Progress: 1},
+				{UserId: "u2", CollectionId: "b", Progress: 1},
+			}, nil
+		},
+		ReplaceRelatedCollectionsFunc: func(similarities []models.CollectionSimilarity) error {
+			stored = similarities
+			return nil
+		},
+	}
+
+	n, err := New(mockStorage, time.Hour).Refresh()
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Len(t, stored, 2)
+}
+
+func TestRefresh_Error(t *testing.T) {
+	mockStorage := &mocks.MockStorage{
+		GetCollectionEngagementFunc: func() ([]models.Engagement, error) {
+			return nil, errors.New("engagement error")
+		},
+	}
+
+	_, err := New(mockStorage, time.Hour).Refresh()
+
+	assert.Error(t, err)
+}
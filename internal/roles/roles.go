@@ -0,0 +1,43 @@
+package roles
+
+const (
+	Viewer     = "viewer"
+	Editor     = "editor"
+	Maintainer = "maintainer"
+	Owner      = "owner"
+)
+
+var rank = map[string]int{
+	Viewer:     1,
+	Editor:     2,
+	Maintainer: 3,
+	Owner:      4,
+}
+
+// Assignable reports whether role can be granted to a collaborator.
+// Ownership is taken from collections.user_id and is never assigned.
+func Assignable(role string) bool {
+	return role == Viewer || role == Editor || role == Maintainer
+}
+
+// Can reports whether role grants at least the required level of access.
+func Can(role, required string) bool {
+	return rank[role] > 0 && rank[role] >= rank[required]
+}
+
+// Outranks reports whether role is strictly above other, which a collaborator needs to grant,
+// change or revoke other.
+func Outranks(role, other string) bool {
+	return rank[role] > rank[other]
+}
+
+// Max returns the highest role of the given ones, or an empty string.
+func Max(roles ...string) string {
+	best := ""
+	for _, role := range roles {
+		if rank[role] > rank[best] {
+			best = role
+		}
+	}
+	return best
+}
@@ -0,0 +1,36 @@
+package roles
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCan(t *testing.T) {
+	assert.True(t, Can(Owner, Maintainer))
+	assert.True(t, Can(Maintainer, Editor))
+	assert.True(t, Can(Editor, Editor))
+	assert.False(t, Can(Viewer, Editor))
+	assert.False(t, Can("", Viewer))
+	assert.False(t, Can("unknown", Viewer))
+}
+
+func TestOutranks(t *testing.T) {
+	assert.True(t, Outranks(Owner, Maintainer))
+	assert.True(t, Outranks(Maintainer, Editor))
+	assert.True(t, Outranks(Editor, ""))
+	assert.False(t, Outranks(Maintainer, Maintainer))
+	assert.False(t, Outranks(Editor, Maintainer))
+}
+
+func TestAssignable(t *testing.T) {
+	assert.True(t, Assignable(Viewer))
+	assert.True(t, Assignable(Maintainer))
+	assert.False(t, Assignable(Owner))
+	assert.False(t, Assignable("admin"))
+}
+
+func TestMax(t *testing.T) {
+	assert.Equal(t, Owner, Max(Viewer, Owner, Editor))
+	assert.Equal(t, Editor, Max("", Editor, Viewer))
+	assert.Equal(t, "", Max())
+}
@@ -42,20 +42,66 @@ func New(ctx handlers.Handlers) {
 	r.Get("/api/collection/{id}", hc.GetCollection)
 	r.Get("/api/collections", hc.GetCollections)
 	r.Get("/api/collections/user", hc.GetUserCollections)
+	r.Get("/api/collections/trending", hc.GetTrendingCollections)
+	r.Get("/api/collections/popular", hc.GetPopularCollections)
+	r.Get("/api/user/report", hc.GetProgressReport)
+	r.Get("/api/user/next", hc.GetNext)
+	r.Get("/api/user/export", hc.ExportAccount)
+	r.Post("/api/user/import", hc.ImportAccount)
 
 	r.Post("/api/collection/{id}/user", hc.AddCollectionToUser)
 	r.Delete("/api/collection/{id}/user", hc.DeleteCollectionFromUser)
+	r.Post("/api/collection/{id}/fork", hc.ForkCollection)
+
+	r.Get("/api/collection/{id}/collaborators", hc.GetCollaborators)
+	r.Post("/api/collection/{id}/collaborators", hc.InviteCollaborator)
+	r.Delete("/api/collection/{id}/collaborators/{userID}", hc.DeleteCollaborator)
+
+	r.Put("/api/collection/{id}/visibility", hc.SetCollectionVisibility)
+	r.Put("/api/collection/{id}/tags", hc.SetCollectionTags)
+	r.Put("/api/material/{id}/tags", hc.SetMaterialTags)
+	r.Get("/api/tags", hc.GetTags)
+
+	r.Get("/api/collection/{id}/reviews", hc.GetReviews)
+	r.Put("/api/collection/{id}/review", hc.SaveReview)
+	r.Delete("/api/collection/{id}/review", hc.DeleteReview)
 
 	r.Post("/api/material", hc.AddMaterial)
 	r.Put("/api/material/{id}", hc.UpdateMaterial)
 	r.Delete("/api/material/{id}", hc.DeleteMaterial)
 	r.Get("/api/material/{id}", hc.GetMaterial)
+	r.Get("/api/book/{isbn}", hc.LookupBook)
 	r.Get("/api/collection/{id}/materials", hc.GetMaterials)
+	r.Put("/api/collection/{id}/materials/order", hc.SetMaterialOrder)
+	r.Delete("/api/collection/{id}/materials/{materialID}", hc.RemoveCollectionMaterial)
+	r.Get("/api/collection/{id}/sections", hc.GetSections)
+	r.Get("/api/collection/{id}/health", hc.GetCollectionHealth)
+	r.Get("/api/collection/{id}/feeds", hc.GetFeeds)
+	r.Post("/api/collection/{id}/feeds", hc.CreateFeed)
+	r.Delete("/api/collection/{id}/feeds/{feedID}", hc.DeleteFeed)
+	r.Post("/api/collection/{id}/import", hc.ImportMaterials)
+	r.Post("/api/collection/{id}/import/link", hc.ImportLink)
+	r.Post("/api/collection/{id}/import/course", hc.ImportCourse)
+	r.Get("/api/collection/{id}/export", hc.ExportCollection)
 
 	r.Post("/api/material/{id}/completed", hc.MarkMaterialAsCompleted)
 	r.Post("/api/material/{id}/incomplete", hc.MarkMaterialAsIncomplete)
 
+	r.Get("/api/material/{id}/comments", hc.GetComments)
+	r.Post("/api/material/{id}/comments", hc.CreateComment)
+	r.Put("/api/comment/{id}", hc.UpdateComment)
+	r.Delete("/api/comment/{id}", hc.DeleteComment)
+
 	r.Post("/api/search", hc.SearchCollectionMaterial)
+	r.Get("/api/search/suggest", hc.Suggest)
+
+	r.Get("/api/searches", hc.GetSavedSearches)
+	r.Post("/api/searches", hc.CreateSavedSearch)
+	r.Delete("/api/searches/{id}", hc.DeleteSavedSearch)
+
+	r.Get("/api/notifications", hc.GetNotifications)
+	r.Post("/api/notifications/{id}/read", hc.MarkNotificationRead)
+	r.Get("/api/recommendations", hc.GetRecommendations)
 
 	r.Get("/api/material/type", hc.GetTypeMaterials)
 
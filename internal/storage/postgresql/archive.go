@@ -0,0 +1,356 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/tags"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// GetAccountArchive collects the collections a user owns with their materials, and the
+// collections the user joined and materials they completed.
+func (s *Storage) GetAccountArchive(userID string) (models.Archive, error) {
+	const op = "storage.postgresql.GetAccountArchive"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	// One snapshot keeps the parts of the archive consistent with each other.
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return models.Archive{}, fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	archive := models.Archive{
+		Version:     models.ArchiveVersion,
+		ExportedAt:  time.Now().UTC(),
+		Collections: []models.ArchiveCollection{},
+		Materials:   []models.ArchiveMaterial{},
+		Memberships: []string{},
+		Completions: []models.ArchiveCompletion{},
+	}
+
+	rows, err := tx.Query(ctx, `
+        SELECT collections.id, collections.created_at, collections.name, COALESCE(collections.description, ''), collections.is_public,
+               COALESCE((SELECT array_agg(tags.name ORDER BY tags.name)
+                   FROM collection_tags INNER JOIN tags ON tags.id = collection_tags.tag_id
+                   WHERE collection_tags.collection_id = collections.id), '{}'),
+               COALESCE((SELECT array_agg(materials.id::text ORDER BY materials.created_at, materials.id)
+                   FROM collection_materials INNER JOIN materials ON materials.id = collection_materials.material_id
+                   WHERE collection_materials.collection_id = collections.id), '{}')
+        FROM collections
+        WHERE collections.user_id = $1
+        ORDER BY collections.created_at, collections.id;
+    `, userID)
+	if err != nil {
+		return models.Archive{}, fmt.Errorf("%s collections: %w", op, err)
+	}
+	for rows.Next() {
+		var collection models.ArchiveCollection
+		if err = rows.Scan(&collection.Id, &collection.CreatedAt, &collection.Name, &collection.Description, &collection.IsPublic, &collection.Tags, &collection.Materials); err != nil {
+			rows.Close()
+			return models.Archive{}, fmt.Errorf("%s collections: %w", op, err)
+		}
+		archive.Collections = append(archive.Collections, collection)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return models.Archive{}, fmt.Errorf("%s collections: %w", op, err)
+	}
+
+	rows, err = tx.Query(ctx, `
+        SELECT materials.id, materials.created_at, materials.name, COALESCE(materials.description, ''), type_materials.name,
+               materials.xp, COALESCE(materials.link, ''),
+               COALESCE((SELECT array_agg(tags.name ORDER BY tags.name)
+                   FROM material_tags INNER JOIN tags ON tags.id = material_tags.tag_id
+                   WHERE material_tags.material_id = materials.id), '{}')
+        FROM materials
+        INNER JOIN type_materials ON type_materials.id = materials.type_id
+        WHERE EXISTS (SELECT 1 FROM collection_materials
+            INNER JOIN collections ON collections.id = collection_materials.collection_id
+            WHERE collection_materials.material_id = materials.id AND collections.user_id = $1)
+        ORDER BY materials.created_at, materials.id;
+    `, userID)
+	if err != nil {
+		return models.Archive{}, fmt.Errorf("%s materials: %w", op, err)
+	}
+	for rows.Next() {
+		var material models.ArchiveMaterial
+		if err = rows.Scan(&material.Id, &material.CreatedAt, &material.Name, &material.Description, &material.Type, &material.Xp, &material.Link, &material.Tags); err != nil {
+			rows.Close()
+			return models.Archive{}, fmt.Errorf("%s materials: %w", op, err)
+		}
+		archive.Materials = append(archive.Materials, material)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return models.Archive{}, fmt.Errorf("%s materials: %w", op, err)
+	}
+
+	rows, err = tx.Query(ctx, `
+        SELECT collection_id FROM user_collections
+        WHERE user_id = $1
+        ORDER BY collection_id;
+    `, userID)
+	if err != nil {
+		return models.Archive{}, fmt.Errorf("%s memberships: %w", op, err)
+	}
+	for rows.Next() {
+		var collectionID string
+		if err = rows.Scan(&collectionID); err != nil {
+			rows.Close()
+			return models.Archive{}, fmt.Errorf("%s memberships: %w", op, err)
+		}
+		archive.Memberships = append(archive.Memberships, collectionID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return models.Archive{}, fmt.Errorf("%s memberships: %w", op, err)
+	}
+
+	rows, err = tx.Query(ctx, `
+        SELECT material_id, completed_at FROM user_materials
+        WHERE user_id = $1 AND completed
+        ORDER BY completed_at NULLS FIRST, material_id;
+    `, userID)
+	if err != nil {
+		return models.Archive{}, fmt.Errorf("%s completions: %w", op, err)
+	}
+	for rows.Next() {
+		var completion models.ArchiveCompletion
+		if err = rows.Scan(&completion.MaterialId, &completion.CompletedAt); err != nil {
+			rows.Close()
+			return models.Archive{}, fmt.Errorf("%s completions: %w", op, err)
+		}
+		archive.Completions = append(archive.Completions, completion)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return models.Archive{}, fmt.Errorf("%s completions: %w", op, err)
+	}
+
+	return archive, nil
+}
+
+// ImportAccountArchive recreates an archive for a user under new ids, all of it or nothing.
+// A collection the user already owns under the same name is reused, as is a material with
+// the same name and link in the target collection. Memberships and completions referring to
+// data outside the archive are restored only when it exists on this instance.
+func (s *Storage) ImportAccountArchive(userID string, archive models.Archive) (models.ArchiveImportResult, error) {
+	const op = "storage.postgresql.ImportAccountArchive"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return models.ArchiveImportResult{}, fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	types := map[string]string{}
+	rows, err := tx.Query(ctx, "SELECT id, name FROM type_materials;")
+	if err != nil {
+		return models.ArchiveImportResult{}, fmt.Errorf("%s types: %w", op, err)
+	}
+	for rows.Next() {
+		var id, name string
+		if err = rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return models.ArchiveImportResult{}, fmt.Errorf("%s types: %w", op, err)
+		}
+		types[name] = id
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return models.ArchiveImportResult{}, fmt.Errorf("%s types: %w", op, err)
+	}
+
+	materials := make(map[string]models.ArchiveMaterial, len(archive.Materials))
+	for _, material := range archive.Materials {
+		materials[material.Id] = material
+	}
+
+	var result models.ArchiveImportResult
+	now := time.Now()
+	collectionIDs := map[string]string{}
+	materialIDs := map[string]string{}
+
+	for _, collection := range archive.Collections {
+		var id string
+		err = tx.QueryRow(ctx, `
+            SELECT id FROM collections
+            WHERE user_id = $1 AND name = $2
+            ORDER BY created_at, id
+            LIMIT 1;
+        `, userID, collection.Name).Scan(&id)
+		switch {
+		case err == nil:
+			result.Collections.Skipped++
+		case errors.Is(err, pgx.ErrNoRows):
+			id = uuid.New().String()
+			createdAt := collection.CreatedAt
+			if createdAt.IsZero() {
+				createdAt = now
+			}
+			_, err = tx.Exec(ctx, `
+                INSERT INTO collections(id, user_id, name, description, created_at, updated_at, is_public)
+                VALUES($1, $2, $3, $4, $5, $6, $7);
+            `, id, userID, collection.Name, collection.Description, createdAt.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), collection.IsPublic)
+			if err != nil {
+				return models.ArchiveImportResult{}, fmt.Errorf("%s collection: %w", op, err)
+			}
+			if err = setCollectionTags(ctx, tx, id, tags.Normalize(collection.Tags)); err != nil {
+				return models.ArchiveImportResult{}, fmt.Errorf("%s collection %w", op, err)
+			}
+			result.Collections.Created++
+		default:
+			return models.ArchiveImportResult{}, fmt.Errorf("%s collection: %w", op, err)
+		}
+		collectionIDs[collection.Id] = id
+
+		for _, archivedID := range collection.Materials {
+			material, ok := materials[archivedID]
+			if !ok {
+				result.Materials.Skipped++
+				continue
+			}
+
+			// A material listed in several collections is created once and linked to each.
+			if materialID, ok := materialIDs[archivedID]; ok {
+				_, err = tx.Exec(ctx, `
+                    INSERT INTO collection_materials(collection_id, material_id)
+                    VALUES($1, $2)
+                    ON CONFLICT DO NOTHING;
+                `, id, materialID)
+				if err != nil {
+					return models.ArchiveImportResult{}, fmt.Errorf("%s material: %w", op, err)
+				}
+				continue
+			}
+
+			var materialID string
+			err = tx.QueryRow(ctx, `
+                SELECT materials.id FROM materials
+                INNER JOIN collection_materials ON materials.id = collection_materials.material_id
+                WHERE collection_materials.collection_id = $1 AND materials.name = $2 AND COALESCE(materials.link, '') = $3
+                LIMIT 1;
+            `, id, material.Name, material.Link).Scan(&materialID)
+			if err == nil {
+				materialIDs[archivedID] = materialID
+				result.Materials.Skipped++
+				continue
+			}
+			if !errors.Is(err, pgx.ErrNoRows) {
+				return models.ArchiveImportResult{}, fmt.Errorf("%s material: %w", op, err)
+			}
+
+			typeID, ok := types[material.Type]
+			if !ok {
+				result.Materials.Skipped++
+				continue
+			}
+
+			materialID, err = createMaterial(ctx, tx, userID, material.Name, material.Description, typeID, material.Xp, material.Link)
+			if err != nil {
+				return models.ArchiveImportResult{}, fmt.Errorf("%s material: %w", op, err)
+			}
+			if err = addMaterialToCollection(ctx, tx, id, materialID); err != nil {
+				return models.ArchiveImportResult{}, fmt.Errorf("%s material: %w", op, err)
+			}
+			if err = setMaterialTags(ctx, tx, materialID, tags.Normalize(material.Tags)); err != nil {
+				return models.ArchiveImportResult{}, fmt.Errorf("%s material %w", op, err)
+			}
+			materialIDs[archivedID] = materialID
+			result.Materials.Created++
+		}
+	}
+
+	for _, archivedID := range archive.Memberships {
+		id, ok := collectionIDs[archivedID]
+		if !ok {
+			if id, err = existingID(ctx, tx, "collections", collectionVisible(2), archivedID, userID); err != nil {
+				return models.ArchiveImportResult{}, fmt.Errorf("%s membership: %w", op, err)
+			}
+		}
+		if id == "" {
+			result.Memberships.Skipped++
+			continue
+		}
+
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO user_collections(user_id, collection_id)
+            VALUES($1, $2)
+            ON CONFLICT DO NOTHING;
+        `, userID, id)
+		if err != nil {
+			return models.ArchiveImportResult{}, fmt.Errorf("%s membership: %w", op, err)
+		}
+		if tag.RowsAffected() == 0 {
+			result.Memberships.Skipped++
+		} else {
+			result.Memberships.Created++
+		}
+	}
+
+	for _, completion := range archive.Completions {
+		id, ok := materialIDs[completion.MaterialId]
+		if !ok {
+			if id, err = existingID(ctx, tx, "materials", materialVisible(2), completion.MaterialId, userID); err != nil {
+				return models.ArchiveImportResult{}, fmt.Errorf("%s completion: %w", op, err)
+			}
+		}
+		if id == "" {
+			result.Completions.Skipped++
+			continue
+		}
+
+		// A completion from the future would count towards streaks and rankings that haven't happened yet.
+		var completedAt any
+		if at := completion.CompletedAt; at != nil {
+			if at.After(now) {
+				at = &now
+			}
+			completedAt = at.Format("2006-01-02 15:04:05")
+		}
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO user_materials(user_id, material_id, completed, completed_at)
+            VALUES($1, $2, true, $3)
+            ON CONFLICT (user_id, material_id) DO UPDATE SET completed = true, completed_at = EXCLUDED.completed_at
+            WHERE NOT user_materials.completed;
+        `, userID, id, completedAt)
+		if err != nil {
+			return models.ArchiveImportResult{}, fmt.Errorf("%s completion: %w", op, err)
+		}
+		if tag.RowsAffected() == 0 {
+			result.Completions.Skipped++
+		} else {
+			result.Completions.Created++
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.ArchiveImportResult{}, fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// existingID returns id when a row of table with that id matches visible for user $2, or an empty string.
+func existingID(ctx context.Context, tx pgx.Tx, table, visible, id, userID string) (string, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return "", nil
+	}
+
+	var exists bool
+	err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM `+table+` WHERE `+table+`.id = $1 AND `+visible+`);`, id, userID).Scan(&exists)
+	if err != nil || !exists {
+		return "", err
+	}
+	return id, nil
+}
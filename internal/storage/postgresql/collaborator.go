@@ -0,0 +1,169 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"github.com/grafchitaru/skillBuilder/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+func (s *Storage) GetCollectionRole(collectionID, userID string) (string, error) {
+	const op = "storage.postgresql.GetCollectionRole"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var role string
+	err := s.pool.QueryRow(ctx, `
+        SELECT CASE WHEN collections.user_id = $2 THEN 'owner' ELSE COALESCE(collection_roles.role, '') END
+        FROM collections
+        LEFT JOIN collection_roles ON collection_roles.collection_id = collections.id AND collection_roles.user_id = $2
+        WHERE collections.id = $1;
+    `, collectionID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", fmt.Errorf("%s: operation timed out: %w", op, err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return role, nil
+}
+
+// GetMaterialRole returns the user's best role over the material across the collections containing it.
+// Inherited links, the materials a fork shares with its origin, grant no role.
+func (s *Storage) GetMaterialRole(materialID, userID string) (string, error) {
+	const op = "storage.postgresql.GetMaterialRole"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT 'owner'
+        FROM materials
+        WHERE materials.id = $1 AND materials.user_id = $2
+        UNION ALL
+        SELECT CASE WHEN collections.user_id = $2 THEN 'owner' ELSE COALESCE(collection_roles.role, '') END
+        FROM collection_materials
+        INNER JOIN collections ON collections.id = collection_materials.collection_id
+        LEFT JOIN collection_roles ON collection_roles.collection_id = collections.id AND collection_roles.user_id = $2
+        WHERE collection_materials.material_id = $1 AND NOT collection_materials.inherited;
+    `, materialID, userID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var found []string
+	for rows.Next() {
+		var role string
+		if err = rows.Scan(&role); err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+		found = append(found, role)
+	}
+
+	if err = rows.Err(); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles.Max(found...), nil
+}
+
+func (s *Storage) AddCollaborator(collectionID, userID, role string) error {
+	const op = "storage.postgresql.AddCollaborator"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO collection_roles(collection_id, user_id, role, created_at, updated_at)
+        VALUES($1, $2, $3, $4, $5)
+        ON CONFLICT (collection_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at;
+    `, collectionID, userID, role, now.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO user_collections(user_id, collection_id)
+        VALUES($1, $2)
+        ON CONFLICT DO NOTHING;
+    `, userID, collectionID)
+	if err != nil {
+		return fmt.Errorf("%s join: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteCollaborator(collectionID, userID string) error {
+	const op = "storage.postgresql.DeleteCollaborator"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err := s.pool.Exec(ctx, `
+        DELETE FROM collection_roles
+        WHERE collection_id=$1 AND user_id=$2;
+    `, collectionID, userID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetCollaborators(collectionID string) ([]models.Collaborator, error) {
+	const op = "storage.postgresql.GetCollaborators"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT collection_roles.collection_id, collection_roles.user_id, users.login, collection_roles.role,
+               collection_roles.created_at, collection_roles.updated_at
+        FROM collection_roles
+        INNER JOIN users ON users.id = collection_roles.user_id
+        WHERE collection_roles.collection_id = $1
+        ORDER BY users.login;
+    `, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var collaborators []models.Collaborator
+	for rows.Next() {
+		var collaborator models.Collaborator
+		if err = rows.Scan(&collaborator.CollectionId, &collaborator.UserId, &collaborator.Login, &collaborator.Role, &collaborator.CreatedAt, &collaborator.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		collaborators = append(collaborators, collaborator)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collaborators, nil
+}
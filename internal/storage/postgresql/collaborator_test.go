@@ -0,0 +1,36 @@
+package postgresql
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/roles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetMaterialRole_Fork(t *testing.T) {
+	s := newTestStorage(t)
+	author := newTestUser(t, s)
+	forker := newTestUser(t, s)
+
+	collectionID, err := s.CreateCollection(author, "Go", "")
+	require.NoError(t, err)
+	materialID := newTestMaterial(t, s, author, collectionID)
+
+	_, err = s.ForkCollection(forker, collectionID, true)
+	require.NoError(t, err)
+
+	role, err := s.GetMaterialRole(materialID, forker)
+	require.NoError(t, err)
+	assert.Equal(t, "", role)
+
+	// Deleting the original clears forked_from of the fork, the link stays inherited.
+	require.NoError(t, s.DeleteCollection(collectionID))
+
+	role, err = s.GetMaterialRole(materialID, forker)
+	require.NoError(t, err)
+	assert.Equal(t, "", role)
+
+	role, err = s.GetMaterialRole(materialID, author)
+	require.NoError(t, err)
+	assert.Equal(t, roles.Owner, role)
+}
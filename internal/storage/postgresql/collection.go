@@ -6,9 +6,71 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/storage"
+	"github.com/jackc/pgx/v5"
 	"time"
 )
 
+const collectionColumns = `collections.id, collections.created_at, collections.updated_at, collections.user_id,
+	collections.name, collections.description, collections.forked_from, collections.is_public,
+	COALESCE((SELECT array_agg(tags.name ORDER BY tags.name)
+		FROM collection_tags INNER JOIN tags ON tags.id = collection_tags.tag_id
+		WHERE collection_tags.collection_id = collections.id), '{}') AS tags,
+	COALESCE((SELECT avg(collection_reviews.rating)::float8
+		FROM collection_reviews WHERE collection_reviews.collection_id = collections.id), 0) AS rating,
+	(SELECT count(*) FROM collection_reviews WHERE collection_reviews.collection_id = collections.id) AS rating_count`
+
+// collectionVisible matches public collections and private ones the user $n owns, collaborates on or has joined.
+func collectionVisible(n int) string {
+	return fmt.Sprintf(`(collections.is_public OR collections.user_id = $%[1]d
+		OR EXISTS (SELECT 1 FROM collection_roles
+			WHERE collection_roles.collection_id = collections.id AND collection_roles.user_id = $%[1]d)
+		OR EXISTS (SELECT 1 FROM user_collections
+			WHERE user_collections.collection_id = collections.id AND user_collections.user_id = $%[1]d))`, n)
+}
+
+// checkCollectionVisible returns storage.ErrNotFound unless the collection exists and is visible to the user.
+func (s *Storage) checkCollectionVisible(ctx context.Context, collectionID, userID string) error {
+	var visible bool
+	err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM collections WHERE collections.id = $1 AND `+collectionVisible(2)+`)`,
+		collectionID, userID).Scan(&visible)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// checkMaterialVisible returns storage.ErrNotFound unless the material exists and is visible to the user.
+func (s *Storage) checkMaterialVisible(ctx context.Context, materialID, userID string) error {
+	var visible bool
+	err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM materials WHERE materials.id = $1 AND `+materialVisible(2)+`)`,
+		materialID, userID).Scan(&visible)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// collectionHasTags matches collections carrying every tag of the text[] parameter $n.
+func collectionHasTags(n int) string {
+	return fmt.Sprintf(`(cardinality($%[1]d::text[]) = 0 OR (
+		SELECT count(*) FROM collection_tags INNER JOIN tags ON tags.id = collection_tags.tag_id
+		WHERE collection_tags.collection_id = collections.id AND tags.name = ANY($%[1]d::text[])
+	) = cardinality($%[1]d::text[]))`, n)
+}
+
+func scanCollection(row pgx.Row, collection *models.Collection, extra ...any) error {
+	dest := []any{&collection.Id, &collection.CreatedAt, &collection.UpdatedAt, &collection.UserId,
+		&collection.Name, &collection.Description, &collection.ForkedFrom, &collection.IsPublic, &collection.Tags, &collection.Rating, &collection.RatingCount, &collection.SumXp, &collection.Xp}
+	return row.Scan(append(dest, extra...)...)
+}
+
 func (s *Storage) CreateCollection(userID, name, description string) (string, error) {
 	const op = "storage.postgresql.CreateCollection"
 
@@ -41,8 +103,8 @@ func (s *Storage) UpdateCollection(collection models.Collection) error {
 	_, err := s.pool.Exec(ctx, `
         UPDATE collections
         SET name=$1, description=$2, updated_at=$3
-        WHERE id=$4 AND user_id=$5;
-    `, collection.Name, collection.Description, now.Format("2006-01-02 15:04:05"), collection.Id, collection.UserId)
+        WHERE id=$4;
+    `, collection.Name, collection.Description, now.Format("2006-01-02 15:04:05"), collection.Id)
 	if err != nil {
 		return fmt.Errorf("%s exec: %w", op, err)
 	}
@@ -50,15 +112,41 @@ func (s *Storage) UpdateCollection(collection models.Collection) error {
 	return nil
 }
 
-func (s *Storage) GetCollections(userID string) ([]models.Collection, error) {
+func (s *Storage) SetCollectionVisibility(collectionID string, isPublic bool) error {
+	const op = "storage.postgresql.SetCollectionVisibility"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	now := time.Now()
+
+	_, err := s.pool.Exec(ctx, `
+        UPDATE collections
+        SET is_public=$1, updated_at=$2
+        WHERE id=$3;
+    `, isPublic, now.Format("2006-01-02 15:04:05"), collectionID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetCollections(userID string, filter models.CollectionFilter) (models.CollectionList, error) {
 	const op = "storage.postgresql.GetCollections"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	query := `
+	p, err := newPager(collectionListOrders, filter.Page)
+	if err != nil {
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{userID, filter.Tags}
+	query, err := p.query(`
 	SELECT
-		collections.*,
+		`+collectionColumns+`,
 		COALESCE(
 			(SELECT SUM(materials.xp)
 			 FROM materials
@@ -75,39 +163,59 @@ func (s *Storage) GetCollections(userID string) ([]models.Collection, error) {
 			   AND user_materials.user_id = $1), 0
 		) AS xp
 	FROM
-		collections;
-	`
+		collections
+	WHERE `+collectionVisible(1)+` AND `+collectionHasTags(2), &args)
+	if err != nil {
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	collections, cursor, err := s.queryCollectionPage(ctx, p, query, args)
+	if err != nil {
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return models.CollectionList{Collections: collections, NextCursor: cursor}, nil
+}
 
-	rows, err := s.pool.Query(ctx, query, userID)
+// queryCollectionPage runs a collection query built by p and returns its rows and the next cursor.
+func (s *Storage) queryCollectionPage(ctx context.Context, p *pager, query string, args queryArgs) ([]models.Collection, string, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", err
 	}
 	defer rows.Close()
 
-	var collections []models.Collection
+	collections := []models.Collection{}
 	for rows.Next() {
 		var collection models.Collection
-		if err = rows.Scan(&collection.Id, &collection.CreatedAt, &collection.UpdatedAt, &collection.UserId, &collection.Name, &collection.Description, &collection.SumXp, &collection.Xp); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+		if err = scanCollection(rows, &collection, p.key()); err != nil {
+			return nil, "", err
 		}
 		collections = append(collections, collection)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, "", err
 	}
 
-	return collections, nil
+	n, cursor := p.next(len(collections))
+	return collections[:n], cursor, nil
 }
 
-func (s *Storage) GetUserCollections(userID string) ([]models.Collection, error) {
+func (s *Storage) GetUserCollections(userID string, page models.Page) (models.CollectionList, error) {
 	const op = "storage.postgresql.GetUserCollections"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
+	p, err := newPager(collectionListOrders, page)
+	if err != nil {
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	//TODO Need optimize SQL Request + add indexes
-	rows, err := s.pool.Query(ctx, `SELECT collections.*,
+	args := queryArgs{userID}
+	query, err := p.query(`SELECT `+collectionColumns+`,
        COALESCE(sum_xp.total_xp, 0) AS sum_xp,
        COALESCE(user_xp.total_xp, 0) AS xp
 FROM collections
@@ -127,29 +235,20 @@ LEFT JOIN (
       AND user_materials.user_id = $1
     GROUP BY collection_materials.collection_id
 ) AS user_xp ON user_xp.collection_id = collections.id
-WHERE user_collections.user_id = $1`, userID)
+WHERE user_collections.user_id = $1`, &args)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf("%s: operation timed out: %w", op, err)
-		}
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
 	}
-	defer rows.Close()
 
-	var collections []models.Collection
-	for rows.Next() {
-		var collection models.Collection
-		if err = rows.Scan(&collection.Id, &collection.CreatedAt, &collection.UpdatedAt, &collection.UserId, &collection.Name, &collection.Description, &collection.SumXp, &collection.Xp); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+	collections, cursor, err := s.queryCollectionPage(ctx, p, query, args)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return models.CollectionList{}, fmt.Errorf("%s: operation timed out: %w", op, err)
 		}
-		collections = append(collections, collection)
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-
-	return collections, nil
+	return models.CollectionList{Collections: collections, NextCursor: cursor}, nil
 }
 
 func (s *Storage) GetCollection(id string, userID string) (models.Collection, error) {
@@ -172,7 +271,7 @@ func (s *Storage) GetCollection(id string, userID string) (models.Collection, er
 	fmt.Printf("GetCollection: id=%s, userID=%s\n", id, userID)
 
 	// TODO Need optimize SQL Request + add indexes
-	err := s.pool.QueryRow(ctx, `SELECT collections.*,
+	row := s.pool.QueryRow(ctx, `SELECT `+collectionColumns+`,
        COALESCE(sum_xp.total_xp, 0) AS sum_xp,
        COALESCE(user_xp.total_xp, 0) AS xp
 FROM collections
@@ -191,9 +290,9 @@ LEFT JOIN (
       AND user_materials.user_id = $1
     GROUP BY collection_materials.collection_id
 ) AS user_xp ON user_xp.collection_id = collections.id
-WHERE collections.id = $2
-`, userID, id).Scan(&collection.Id, &collection.CreatedAt, &collection.UpdatedAt, &collection.UserId, &collection.Name, &collection.Description, &collection.SumXp, &collection.Xp)
-	if err != nil {
+WHERE collections.id = $2 AND `+collectionVisible(1)+`
+`, userID, id)
+	if err := scanCollection(row, &collection); err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			return models.Collection{}, fmt.Errorf("%s: operation timed out: %w", op, err)
 		}
@@ -209,11 +308,13 @@ func (s *Storage) AddCollectionToUser(userID, collectionID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
+	now := time.Now()
+
 	_, err := s.pool.Exec(ctx, `
-        INSERT INTO user_collections(user_id, collection_id)
-        VALUES($1, $2)
+        INSERT INTO user_collections(user_id, collection_id, joined_at)
+        VALUES($1, $2, $3)
         ON CONFLICT DO NOTHING;
-    `, userID, collectionID)
+    `, userID, collectionID, now.Format("2006-01-02 15:04:05"))
 	if err != nil {
 		return fmt.Errorf("%s exec: %w", op, err)
 	}
@@ -238,7 +339,7 @@ func (s *Storage) DeleteCollectionFromUser(userID, collectionID string) error {
 	return nil
 }
 
-func (s *Storage) DeleteCollection(userID, collectionID string) error {
+func (s *Storage) DeleteCollection(collectionID string) error {
 	const op = "storage.postgresql.DeleteCollection"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
@@ -246,8 +347,8 @@ func (s *Storage) DeleteCollection(userID, collectionID string) error {
 
 	_, err := s.pool.Exec(ctx, `
         DELETE FROM collections
-        WHERE user_id=$1 AND id=$2;
-    `, userID, collectionID)
+        WHERE id=$1;
+    `, collectionID)
 	if err != nil {
 		return fmt.Errorf("%s exec: %w", op, err)
 	}
@@ -255,45 +356,184 @@ func (s *Storage) DeleteCollection(userID, collectionID string) error {
 	return nil
 }
 
-func (s *Storage) SearchCollections(query string, userID string) ([]models.Collection, error) {
+func (s *Storage) SearchCollections(search models.SearchQuery, userID string) (models.CollectionList, error) {
 	const op = "storage.postgresql.SearchCollections"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	//TODO Need optimize SQL Request + add indexes
-	rows, err := s.pool.Query(ctx, "SELECT collections.*, "+
-		"( "+
-		"SELECT sum(materials.xp) "+
-		"FROM materials WHERE materials.id IN (SELECT collection_materials.material_id FROM collection_materials WHERE collection_materials.collection_id = collections.id) "+
-		") AS sum_xp, "+
-		"( "+
-		"SELECT (SELECT sum(materials.xp) FROM materials WHERE materials.id = user_materials.material_id) "+
-		"FROM user_materials WHERE user_materials.material_id IN (SELECT collection_materials.material_id FROM collection_materials WHERE collection_materials.collection_id = collections.id) "+
-		"AND user_materials.completed = true AND user_materials.user_id = $1 "+
-		") AS xp "+
-		"FROM collections "+
-		" WHERE name LIKE '%'||$2||'%' OR description LIKE '%'||$2||'%'", userID, query)
+	p, err := newPager(collectionSearchOrders, search.Page)
+	if err != nil {
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{}
+	from := searchCollectionsFrom(args.add(userID))
+	text := args.add(search.Query)
+	where := collectionSearchFilter(search, userID, text, &args)
+	query, err := p.query("SELECT "+collectionColumns+", collections.sum_xp, collections.xp, "+
+		searchRank("collections", text)+" AS rank, "+
+		searchHighlight("collections", text)+" AS highlight "+
+		"FROM "+from+" WHERE "+where, &args)
+	if err != nil {
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf("%s: operation timed out: %w", op, err)
+			return models.CollectionList{}, fmt.Errorf("%s: operation timed out: %w", op, err)
 		}
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var collections []models.Collection
+	collections := []models.Collection{}
 	for rows.Next() {
 		var collection models.Collection
-		if err = rows.Scan(&collection.Id, &collection.CreatedAt, &collection.UpdatedAt, &collection.UserId, &collection.Name, &collection.Description, &collection.SumXp, &collection.Xp); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+		if err = scanCollection(rows, &collection, &collection.Rank, &collection.Highlight, p.key()); err != nil {
+			return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
 		}
+		collection.Highlight = highlightHTML(collection.Highlight)
 		collections = append(collections, collection)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.CollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, cursor := p.next(len(collections))
+	return models.CollectionList{Collections: collections[:n], NextCursor: cursor}, nil
+}
+
+// ForkCollection creates a collection owned by the user with the materials of the original.
+// Materials are linked when the user keeps progress, which is stored per material, and copied
+// otherwise so the fork starts afresh.
+func (s *Storage) ForkCollection(userID, collectionID string, keepProgress bool) (string, error) {
+	const op = "storage.postgresql.ForkCollection"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var name, description string
+	var isPublic bool
+	err = tx.QueryRow(ctx, `
+        SELECT name, COALESCE(description, ''), is_public
+        FROM collections
+        WHERE id=$1;
+    `, collectionID).Scan(&name, &description, &isPublic)
+	if err != nil {
+		return "", fmt.Errorf("%s source: %w", op, err)
+	}
+
+	id := uuid.New()
+	now := time.Now()
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO collections(id, user_id, name, description, created_at, updated_at, forked_from, is_public)
+        VALUES($1, $2, $3, $4, $5, $6, $7, $8);
+    `, id, userID, name, description, now.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), collectionID, isPublic)
+	if err != nil {
+		return "", fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO collection_tags(collection_id, tag_id)
+        SELECT $1, tag_id FROM collection_tags WHERE collection_id=$2;
+    `, id, collectionID)
+	if err != nil {
+		return "", fmt.Errorf("%s copy tags: %w", op, err)
+	}
+
+	if keepProgress {
+		// The links are inherited, owning the fork gives no rights over the original author's materials.
+		_, err = tx.Exec(ctx, `
+            INSERT INTO collection_materials(collection_id, material_id, position, inherited)
+            SELECT $1, material_id, position, true FROM collection_materials WHERE collection_id=$2;
+        `, id, collectionID)
+		if err != nil {
+			return "", fmt.Errorf("%s link materials: %w", op, err)
+		}
+	} else if err = copyMaterials(ctx, tx, userID, collectionID, id.String(), now); err != nil {
+		return "", fmt.Errorf("%s copy materials: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO user_collections(user_id, collection_id)
+        VALUES($1, $2)
+        ON CONFLICT DO NOTHING;
+    `, userID, id)
+	if err != nil {
+		return "", fmt.Errorf("%s join: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return id.String(), nil
+}
+
+// copyMaterials gives the fork its own copies of the original's materials, owned by the user.
+func copyMaterials(ctx context.Context, tx pgx.Tx, userID, collectionID, forkID string, now time.Time) error {
+	rows, err := tx.Query(ctx, `
+        SELECT material_id, position FROM collection_materials WHERE collection_id=$1;
+    `, collectionID)
+	if err != nil {
+		return err
+	}
+
+	type link struct {
+		materialID string
+		position   *int
+	}
+	var links []link
+	for rows.Next() {
+		var l link
+		if err = rows.Scan(&l.materialID, &l.position); err != nil {
+			rows.Close()
+			return err
+		}
+		links = append(links, l)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
 	}
 
-	return collections, nil
+	for _, l := range links {
+		materialID := uuid.New()
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO materials(id, user_id, name, description, created_at, updated_at, type_id, xp, link)
+            SELECT $1, $2, name, description, $3, $3, type_id, xp, link FROM materials WHERE id=$4;
+        `, materialID, userID, now.Format("2006-01-02 15:04:05"), l.materialID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO material_tags(material_id, tag_id)
+            SELECT $1, tag_id FROM material_tags WHERE material_id=$2;
+        `, materialID, l.materialID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO collection_materials(collection_id, material_id, position)
+            VALUES($1, $2, $3);
+        `, forkID, materialID, l.position)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
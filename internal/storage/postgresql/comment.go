@@ -0,0 +1,267 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// Threads are listed oldest first, as a conversation reads.
+var commentListOrders = map[string]listOrder{
+	"": {keys: []sortKey{createdAtKey}},
+}
+
+const commentColumns = `material_comments.id, material_comments.created_at, material_comments.updated_at,
+	material_comments.material_id, material_comments.user_id, users.login, material_comments.parent_id,
+	material_comments.text, material_comments.deleted_at IS NOT NULL`
+
+func scanComment(row pgx.Row, c *models.Comment) error {
+	err := row.Scan(&c.Id, &c.CreatedAt, &c.UpdatedAt, &c.MaterialId, &c.UserId, &c.Login, &c.ParentId, &c.Text, &c.Deleted)
+	if err != nil {
+		return err
+	}
+	if c.Deleted {
+		c.Text = ""
+	}
+	return nil
+}
+
+func (s *Storage) CreateComment(materialID, userID, parentID, text string) (string, error) {
+	const op = "storage.postgresql.CreateComment"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	if err := s.checkMaterialVisible(ctx, materialID, userID); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	id := uuid.New()
+	now := time.Now()
+	parent := sql.NullString{String: parentID, Valid: parentID != ""}
+
+	_, err := s.pool.Exec(ctx, `
+        INSERT INTO material_comments(id, created_at, updated_at, material_id, user_id, parent_id, text)
+        VALUES($1, $2, $3, $4, $5, $6, $7);
+    `, id, now.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), materialID, userID, parent, text)
+	if err != nil {
+		return "", fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return id.String(), nil
+}
+
+func (s *Storage) GetComment(commentID string) (models.Comment, error) {
+	const op = "storage.postgresql.GetComment"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var comment models.Comment
+
+	err := scanComment(s.pool.QueryRow(ctx, "SELECT "+commentColumns+`
+        FROM material_comments
+        INNER JOIN users ON users.id = material_comments.user_id
+        WHERE material_comments.id = $1`, commentID), &comment)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return models.Comment{}, fmt.Errorf("%s: operation timed out: %w", op, err)
+		}
+		return models.Comment{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return comment, nil
+}
+
+func (s *Storage) GetComments(materialID, userID string, page models.Page) (models.CommentList, error) {
+	const op = "storage.postgresql.GetComments"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p, err := newPager(commentListOrders, page)
+	if err != nil {
+		return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.checkMaterialVisible(ctx, materialID, userID); err != nil {
+		return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	list := models.CommentList{Comments: []models.Comment{}}
+
+	err = s.pool.QueryRow(ctx, `
+        SELECT count(*) FROM material_comments WHERE material_id = $1 AND parent_id IS NULL;
+    `, materialID).Scan(&list.Total)
+	if err != nil {
+		return models.CommentList{}, fmt.Errorf("%s count: %w", op, err)
+	}
+
+	// Top-level comments are paginated, replies are loaded in full for every thread on the page.
+	args := queryArgs{materialID}
+	query, err := p.query(`
+        SELECT material_comments.id, material_comments.created_at
+        FROM material_comments
+        WHERE material_comments.material_id = $1 AND material_comments.parent_id IS NULL`, &args)
+	if err != nil {
+		return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+	}
+	var roots []string
+	for rows.Next() {
+		var id string
+		var createdAt time.Time
+		if err = rows.Scan(&id, &createdAt, p.key()); err != nil {
+			rows.Close()
+			return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+		}
+		roots = append(roots, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, cursor := p.next(len(roots))
+	list.NextCursor = cursor
+	if n == 0 {
+		return list, nil
+	}
+
+	rows, err = s.pool.Query(ctx, `
+        WITH RECURSIVE thread AS (
+            SELECT material_comments.*
+            FROM material_comments
+            WHERE material_comments.id = ANY($1::uuid[])
+            UNION ALL
+            SELECT material_comments.*
+            FROM material_comments
+            INNER JOIN thread ON material_comments.parent_id = thread.id
+        )
+        SELECT `+commentColumns+`
+        FROM thread AS material_comments
+        INNER JOIN users ON users.id = material_comments.user_id
+        ORDER BY material_comments.created_at, material_comments.id;
+    `, roots[:n])
+	if err != nil {
+		return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var comments []models.Comment
+	for rows.Next() {
+		var comment models.Comment
+		if err = scanComment(rows, &comment); err != nil {
+			return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return models.CommentList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	list.Comments = buildThreads(comments, "")
+
+	return list, nil
+}
+
+func buildThreads(comments []models.Comment, parentID string) []models.Comment {
+	thread := []models.Comment{}
+	for _, comment := range comments {
+		if comment.ParentId.String != parentID {
+			continue
+		}
+		comment.Replies = buildThreads(comments, comment.Id)
+		thread = append(thread, comment)
+	}
+	return thread
+}
+
+func (s *Storage) UpdateComment(commentID, text string) error {
+	const op = "storage.postgresql.UpdateComment"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	now := time.Now()
+
+	_, err := s.pool.Exec(ctx, `
+        UPDATE material_comments
+        SET text = $2, updated_at = $3
+        WHERE id = $1 AND deleted_at IS NULL;
+    `, commentID, text, now.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteComment(commentID string) error {
+	const op = "storage.postgresql.DeleteComment"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// A comment with replies stays in place as a tombstone so the thread isn't lost.
+	_, err = tx.Exec(ctx, `
+        DELETE FROM material_comments
+        WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM material_comments replies WHERE replies.parent_id = $1);
+    `, commentID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	now := time.Now()
+
+	_, err = tx.Exec(ctx, `
+        UPDATE material_comments
+        SET deleted_at = $2, deleted_by = user_id
+        WHERE id = $1 AND deleted_at IS NULL;
+    `, commentID, now.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return fmt.Errorf("%s soft delete: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) HideComment(commentID, moderatorID string) error {
+	const op = "storage.postgresql.HideComment"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	now := time.Now()
+
+	_, err := s.pool.Exec(ctx, `
+        UPDATE material_comments
+        SET deleted_at = $2, deleted_by = $3
+        WHERE id = $1 AND deleted_at IS NULL;
+    `, commentID, now.Format("2006-01-02 15:04:05"), moderatorID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package postgresql
+
+import (
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetComments_Pages(t *testing.T) {
+	s := newTestStorage(t)
+	author := newTestUser(t, s)
+
+	collectionID, err := s.CreateCollection(author, "Go", "")
+	require.NoError(t, err)
+	materialID := newTestMaterial(t, s, author, collectionID)
+
+	var roots []string
+	for _, text := range []string{"first", "second", "third"} {
+		id, err := s.CreateComment(materialID, author, "", text)
+		require.NoError(t, err)
+		roots = append(roots, id)
+	}
+	_, err = s.CreateComment(materialID, author, roots[1], "reply")
+	require.NoError(t, err)
+
+	// Comments of the same second are ordered by id, so only the split into pages is certain.
+	first, err := s.GetComments(materialID, author, models.Page{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Comments, 2)
+	assert.Equal(t, 3, first.Total)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := s.GetComments(materialID, author, models.Page{Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, second.Comments, 1)
+	assert.Empty(t, second.NextCursor)
+
+	seen := map[string][]models.Comment{}
+	for _, comment := range append(first.Comments, second.Comments...) {
+		seen[comment.Id] = comment.Replies
+	}
+	assert.Len(t, seen, 3)
+	require.Len(t, seen[roots[1]], 1)
+	assert.Equal(t, "reply", seen[roots[1]][0].Text)
+}
@@ -0,0 +1,179 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// CreateFeed subscribes the collection to a feed, or returns the id of its existing
+// subscription to the same URL.
+func (s *Storage) CreateFeed(collectionID, userID, url, title string) (string, error) {
+	const op = "storage.postgresql.CreateFeed"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	id := uuid.New().String()
+	now := time.Now()
+
+	err := s.pool.QueryRow(ctx, `
+        INSERT INTO collection_feeds(id, collection_id, user_id, url, title, created_at, updated_at)
+        VALUES($1, $2, $3, $4, $5, $6, $6)
+        ON CONFLICT (collection_id, url) DO UPDATE SET updated_at = collection_feeds.updated_at
+        RETURNING id;
+    `, id, collectionID, userID, url, title, now.Format("2006-01-02 15:04:05")).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetFeeds(collectionID, userID string) ([]models.Feed, error) {
+	const op = "storage.postgresql.GetFeeds"
+
+	return s.queryFeeds(op, `
+        INNER JOIN collections ON collections.id = collection_feeds.collection_id
+        WHERE collection_feeds.collection_id = $1 AND `+collectionVisible(2)+`
+        ORDER BY collection_feeds.created_at, collection_feeds.id`, collectionID, userID)
+}
+
+func (s *Storage) GetAllFeeds() ([]models.Feed, error) {
+	const op = "storage.postgresql.GetAllFeeds"
+
+	return s.queryFeeds(op, "ORDER BY collection_feeds.checked_at NULLS FIRST, collection_feeds.id")
+}
+
+func (s *Storage) queryFeeds(op, where string, args ...any) ([]models.Feed, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT collection_feeds.id, collection_feeds.created_at, collection_feeds.collection_id, collection_feeds.user_id,
+               collection_feeds.url, collection_feeds.title,
+               CASE WHEN collection_feeds.checked_at IS NULL THEN '`+models.FeedPending+`'
+                    WHEN collection_feeds.last_error IS NULL THEN '`+models.FeedOK+`'
+                    ELSE '`+models.FeedError+`' END,
+               collection_feeds.checked_at, COALESCE(collection_feeds.last_error, ''),
+               (SELECT COUNT(*) FROM feed_entries WHERE feed_entries.feed_id = collection_feeds.id)
+        FROM collection_feeds `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	feeds := []models.Feed{}
+	for rows.Next() {
+		var feed models.Feed
+		if err = rows.Scan(&feed.Id, &feed.CreatedAt, &feed.CollectionId, &feed.UserId, &feed.URL, &feed.Title,
+			&feed.Status, &feed.CheckedAt, &feed.LastError, &feed.Entries); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		feeds = append(feeds, feed)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return feeds, nil
+}
+
+func (s *Storage) DeleteFeed(feedID, collectionID string) error {
+	const op = "storage.postgresql.DeleteFeed"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err := s.pool.Exec(ctx, `
+        DELETE FROM collection_feeds
+        WHERE id=$1 AND collection_id=$2;
+    `, feedID, collectionID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddFeedEntries creates materials in the feed's collection for entries whose key the feed
+// hasn't seen, owned by the user who subscribed. It returns how many were created.
+func (s *Storage) AddFeedEntries(feed models.Feed, entries []models.FeedEntry) (int, error) {
+	const op = "storage.postgresql.AddFeedEntries"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	created := 0
+	for _, entry := range entries {
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO feed_entries(feed_id, key, created_at)
+            VALUES($1, $2, $3)
+            ON CONFLICT DO NOTHING;
+        `, feed.Id, entry.Key, now.Format("2006-01-02 15:04:05"))
+		if err != nil {
+			return 0, fmt.Errorf("%s exec: %w", op, err)
+		}
+		if tag.RowsAffected() == 0 {
+			continue
+		}
+
+		material := entry.Material
+		id, err := createMaterial(ctx, tx, feed.UserId, material.Name, material.Description, material.TypeId, material.Xp, material.Link)
+		if err != nil {
+			return 0, fmt.Errorf("%s exec: %w", op, err)
+		}
+
+		if err = addMaterialToCollection(ctx, tx, feed.CollectionId, id); err != nil {
+			return 0, fmt.Errorf("%s exec: %w", op, err)
+		}
+
+		_, err = tx.Exec(ctx, `
+            UPDATE feed_entries SET material_id=$3
+            WHERE feed_id=$1 AND key=$2;
+        `, feed.Id, entry.Key, id)
+		if err != nil {
+			return 0, fmt.Errorf("%s exec: %w", op, err)
+		}
+
+		created++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// UpdateFeedStatus records a check of the feed; an empty lastError means it succeeded. An
+// empty title keeps the previous one.
+func (s *Storage) UpdateFeedStatus(feedID, title, lastError string) error {
+	const op = "storage.postgresql.UpdateFeedStatus"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	now := time.Now()
+
+	_, err := s.pool.Exec(ctx, `
+        UPDATE collection_feeds
+        SET title=COALESCE(NULLIF($2, ''), title), checked_at=$3, updated_at=$3, last_error=NULLIF($4, '')
+        WHERE id=$1;
+    `, feedID, title, now.Format("2006-01-02 15:04:05"), lastError)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// GetLinksToCheck returns up to limit materials whose http(s) link was never checked, has
+// changed since its last check, or is due for another one, least recently checked first.
+func (s *Storage) GetLinksToCheck(limit int) ([]models.LinkCheck, error) {
+	const op = "storage.postgresql.GetLinksToCheck"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT materials.id, materials.name, materials.link,
+               CASE WHEN link_checks.link = materials.link THEN link_checks.failures ELSE 0 END
+        FROM materials
+        LEFT JOIN link_checks ON link_checks.material_id = materials.id
+        WHERE (materials.link LIKE 'http://%' OR materials.link LIKE 'https://%')
+          AND (link_checks.material_id IS NULL OR link_checks.link <> materials.link OR link_checks.next_check_at <= $1)
+        ORDER BY link_checks.checked_at NULLS FIRST, materials.id
+        LIMIT $2;
+    `, time.Now().Format("2006-01-02 15:04:05"), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	checks := []models.LinkCheck{}
+	for rows.Next() {
+		var check models.LinkCheck
+		if err = rows.Scan(&check.MaterialId, &check.Name, &check.Link, &check.Failures); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		checks = append(checks, check)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return checks, nil
+}
+
+// SaveLinkCheck records the outcome of checking a material's link and when to check it next.
+func (s *Storage) SaveLinkCheck(check models.LinkCheck, nextCheck time.Time) error {
+	const op = "storage.postgresql.SaveLinkCheck"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	checkedAt := time.Now()
+	if check.CheckedAt != nil {
+		checkedAt = *check.CheckedAt
+	}
+
+	_, err := s.pool.Exec(ctx, `
+        INSERT INTO link_checks(material_id, link, status_code, redirect_to, last_error, failures, checked_at, next_check_at)
+        VALUES($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8)
+        ON CONFLICT (material_id) DO UPDATE
+        SET link=EXCLUDED.link, status_code=EXCLUDED.status_code, redirect_to=EXCLUDED.redirect_to,
+            last_error=EXCLUDED.last_error, failures=EXCLUDED.failures,
+            checked_at=EXCLUDED.checked_at, next_check_at=EXCLUDED.next_check_at;
+    `, check.MaterialId, check.Link, check.StatusCode, check.RedirectTo, check.LastError, check.Failures,
+		checkedAt.Format("2006-01-02 15:04:05"), nextCheck.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetCollectionHealth returns the checks of the collection's material links that are broken
+// or redirected. Checks of a link the material no longer has are ignored.
+func (s *Storage) GetCollectionHealth(collectionID string) (models.CollectionHealth, error) {
+	const op = "storage.postgresql.GetCollectionHealth"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	health := models.CollectionHealth{Broken: []models.LinkCheck{}, Redirected: []models.LinkCheck{}}
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT materials.id, materials.name, materials.link,
+               COALESCE(link_checks.status_code, 0), COALESCE(link_checks.redirect_to, ''),
+               COALESCE(link_checks.last_error, ''), COALESCE(link_checks.failures, 0), link_checks.checked_at
+        FROM collection_materials
+        INNER JOIN materials ON materials.id = collection_materials.material_id
+        LEFT JOIN link_checks ON link_checks.material_id = materials.id AND link_checks.link = materials.link
+        WHERE collection_materials.collection_id = $1 AND (materials.link LIKE 'http://%' OR materials.link LIKE 'https://%')
+        ORDER BY materials.name, materials.id;
+    `, collectionID)
+	if err != nil {
+		return health, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var check models.LinkCheck
+		if err = rows.Scan(&check.MaterialId, &check.Name, &check.Link, &check.StatusCode, &check.RedirectTo,
+			&check.LastError, &check.Failures, &check.CheckedAt); err != nil {
+			return health, fmt.Errorf("%s: %w", op, err)
+		}
+
+		health.Links++
+		if check.CheckedAt == nil {
+			continue
+		}
+		health.Checked++
+		switch {
+		case check.Broken():
+			health.Broken = append(health.Broken, check)
+		case check.RedirectTo != "":
+			health.Redirected = append(health.Redirected, check)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return health, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return health, nil
+}
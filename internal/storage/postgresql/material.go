@@ -6,24 +6,61 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"time"
 )
 
+const materialColumns = `materials.id, materials.created_at, materials.updated_at, materials.user_id, materials.name,
+	materials.description, materials.type_id, materials.xp, materials.link,
+	COALESCE((SELECT array_agg(tags.name ORDER BY tags.name)
+		FROM material_tags INNER JOIN tags ON tags.id = material_tags.tag_id
+		WHERE material_tags.material_id = materials.id), '{}') AS tags`
+
+// materialHasTags matches materials carrying every tag of the text[] parameter $n.
+func materialHasTags(n int) string {
+	return fmt.Sprintf(`(cardinality($%[1]d::text[]) = 0 OR (
+		SELECT count(*) FROM material_tags INNER JOIN tags ON tags.id = material_tags.tag_id
+		WHERE material_tags.material_id = materials.id AND tags.name = ANY($%[1]d::text[])
+	) = cardinality($%[1]d::text[]))`, n)
+}
+
+func scanMaterial(row pgx.Row, material *models.Material, extra ...any) error {
+	dest := []any{&material.Id, &material.CreatedAt, &material.UpdatedAt, &material.UserId, &material.Name,
+		&material.Description, &material.TypeId, &material.Xp, &material.Link, &material.Tags}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// execer runs statements on the pool or within a transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
 func (s *Storage) CreateMaterial(userID string, name string, description string, typeId string, xp int, link string) (string, error) {
 	const op = "storage.postgresql.CreateMaterial"
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
+	id, err := createMaterial(ctx, s.pool, userID, name, description, typeId, xp, link)
+	if err != nil {
+		return "", fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func createMaterial(ctx context.Context, db execer, userID string, name string, description string, typeId string, xp int, link string) (string, error) {
 	id := uuid.New()
 
 	now := time.Now()
 
-	_, err := s.pool.Exec(ctx, `
+	_, err := db.Exec(ctx, `
         INSERT INTO materials(id, user_id, name, description, created_at, updated_at, type_id, xp, link)
         VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9);
     `, id, userID, name, description, now.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), typeId, xp, link)
 	if err != nil {
-		return "", fmt.Errorf("%s exec: %w", op, err)
+		return "", err
 	}
 
 	return id.String(), nil
@@ -40,8 +77,8 @@ func (s *Storage) UpdateMaterial(material models.Material) error {
 	_, err := s.pool.Exec(ctx, `
         UPDATE materials
         SET name=$1, description=$2, type_id=$3, link=$4, xp=$5, updated_at=$6
-        WHERE id=$7 AND user_id=$8;
-    `, material.Name, material.Description, material.TypeId, material.Link, material.Xp, now.Format("2006-01-02 15:04:05"), material.Id, material.UserId)
+        WHERE id=$7;
+    `, material.Name, material.Description, material.TypeId, material.Link, material.Xp, now.Format("2006-01-02 15:04:05"), material.Id)
 	if err != nil {
 		return fmt.Errorf("%s exec: %w", op, err)
 	}
@@ -49,7 +86,7 @@ func (s *Storage) UpdateMaterial(material models.Material) error {
 	return nil
 }
 
-func (s *Storage) DeleteMaterial(userID, materialID string) error {
+func (s *Storage) DeleteMaterial(materialID string) error {
 	const op = "storage.postgresql.DeleteMaterial"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
@@ -57,8 +94,8 @@ func (s *Storage) DeleteMaterial(userID, materialID string) error {
 
 	_, err := s.pool.Exec(ctx, `
         DELETE FROM materials
-        WHERE id=$1 AND user_id=$2;
-    `, materialID, userID)
+        WHERE id=$1;
+    `, materialID)
 	if err != nil {
 		return fmt.Errorf("%s exec: %w", op, err)
 	}
@@ -66,44 +103,60 @@ func (s *Storage) DeleteMaterial(userID, materialID string) error {
 	return nil
 }
 
-func (s *Storage) GetMaterials(collectionID, userID string) ([]models.Material, error) {
+func (s *Storage) GetMaterials(collectionID, userID string, filter models.MaterialFilter) (models.MaterialList, error) {
 	const op = "storage.postgresql.GetMaterials"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	rows, err := s.pool.Query(ctx, `
-		SELECT materials.*,
+	p, err := newPager(materialListOrders, filter.Page)
+	if err != nil {
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.checkCollectionVisible(ctx, collectionID, userID); err != nil {
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{collectionID, userID, filter.Tags}
+	query, err := p.query(`
+		SELECT `+materialColumns+`,
 		       COALESCE(user_materials.completed, false) AS completed
 		FROM materials
 		INNER JOIN collection_materials ON materials.id = collection_materials.material_id
 		LEFT JOIN user_materials ON materials.id = user_materials.material_id AND user_materials.user_id = $2
-		WHERE collection_materials.collection_id = $1
-	`, collectionID, userID)
+		WHERE collection_materials.collection_id = $1 AND `+materialHasTags(3), &args)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var materials []models.Material
+	materials := []models.Material{}
 	for rows.Next() {
 		var material models.Material
 		var completed bool
-		if err := rows.Scan(&material.Id, &material.CreatedAt, &material.UpdatedAt, &material.UserId, &material.Name, &material.Description, &material.TypeId, &material.Xp, &material.Link, &completed); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+		if err := scanMaterial(rows, &material, &completed, p.key()); err != nil {
+			return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
 		}
 		material.Completed = completed
 		materials = append(materials, material)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return materials, nil
+	n, cursor := p.next(len(materials))
+	return models.MaterialList{Materials: materials[:n], NextCursor: cursor}, nil
 }
 
-func (s *Storage) GetMaterial(materialID string) (models.Material, error) {
+// GetMaterial returns the material if the user owns it or can see a collection containing it.
+func (s *Storage) GetMaterial(materialID, userID string) (models.Material, error) {
 	const op = "storage.postgresql.GetMaterial"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
@@ -111,8 +164,12 @@ func (s *Storage) GetMaterial(materialID string) (models.Material, error) {
 
 	var material models.Material
 
-	err := s.pool.QueryRow(ctx, "SELECT * FROM materials WHERE id = $1", materialID).Scan(&material.Id, &material.CreatedAt, &material.UpdatedAt, &material.UserId, &material.Name, &material.Description, &material.TypeId, &material.Xp, &material.Link)
+	err := scanMaterial(s.pool.QueryRow(ctx, "SELECT "+materialColumns+" FROM materials WHERE id = $1 AND "+materialVisible(2),
+		materialID, userID), &material)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Material{}, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			return models.Material{}, fmt.Errorf("%s: operation timed out: %w", op, err)
 		}
@@ -128,10 +185,54 @@ func (s *Storage) AddMaterialToCollection(collectionID, materialID string) error
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	_, err := s.pool.Exec(ctx, `
+	if err := addMaterialToCollection(ctx, s.pool, collectionID, materialID); err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+func addMaterialToCollection(ctx context.Context, db execer, collectionID, materialID string) error {
+	_, err := db.Exec(ctx, `
         INSERT INTO collection_materials(collection_id, material_id)
         VALUES($1, $2);
     `, collectionID, materialID)
+	return err
+}
+
+// RemoveMaterialFromCollection unlinks the material from the collection, the material itself stays.
+func (s *Storage) RemoveMaterialFromCollection(collectionID, materialID string) error {
+	const op = "storage.postgresql.RemoveMaterialFromCollection"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tag, err := s.pool.Exec(ctx, `
+        DELETE FROM collection_materials
+        WHERE collection_id=$1 AND material_id=$2;
+    `, collectionID, materialID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetMaterialOrder positions the collection's materials in the given order, materials left out keep theirs.
+func (s *Storage) SetMaterialOrder(collectionID string, materialIDs []string) error {
+	const op = "storage.postgresql.SetMaterialOrder"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err := s.pool.Exec(ctx, `
+        UPDATE collection_materials SET position = ordered.position
+        FROM unnest($2::uuid[]) WITH ORDINALITY AS ordered(material_id, position)
+        WHERE collection_materials.collection_id = $1 AND collection_materials.material_id = ordered.material_id;
+    `, collectionID, materialIDs)
 	if err != nil {
 		return fmt.Errorf("%s exec: %w", op, err)
 	}
@@ -139,6 +240,46 @@ func (s *Storage) AddMaterialToCollection(collectionID, materialID string) error
 	return nil
 }
 
+// ImportMaterials creates materials in a collection, all of them or none.
+func (s *Storage) ImportMaterials(collectionID, userID string, materials []models.NewMaterial) ([]string, error) {
+	const op = "storage.postgresql.ImportMaterials"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	ids := make([]string, 0, len(materials))
+	for _, material := range materials {
+		id, err := createMaterial(ctx, tx, userID, material.Name, material.Description, material.TypeId, material.Xp, material.Link)
+		if err != nil {
+			return nil, fmt.Errorf("%s exec: %w", op, err)
+		}
+
+		if err = addMaterialToCollection(ctx, tx, collectionID, id); err != nil {
+			return nil, fmt.Errorf("%s exec: %w", op, err)
+		}
+
+		if len(material.Tags) > 0 {
+			if err = setMaterialTags(ctx, tx, id, material.Tags); err != nil {
+				return nil, fmt.Errorf("%s %w", op, err)
+			}
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return ids, nil
+}
+
 func (s *Storage) MarkMaterialAsCompleted(userID, materialID string) error {
 	const op = "storage.postgresql.MarkMaterialAsCompleted"
 
@@ -148,14 +289,14 @@ func (s *Storage) MarkMaterialAsCompleted(userID, materialID string) error {
 	_, err := s.pool.Exec(ctx, `
         WITH upsert AS (
     UPDATE user_materials
-    SET completed = true
+    SET completed = true, completed_at = CASE WHEN completed THEN completed_at ELSE $3 END
     WHERE user_id = $1 AND material_id = $2
     RETURNING *
 	)
-	INSERT INTO user_materials (user_id, material_id, completed)
-	SELECT $1, $2, true
+	INSERT INTO user_materials (user_id, material_id, completed, completed_at)
+	SELECT $1, $2, true, $3
 	WHERE NOT EXISTS (SELECT * FROM upsert);
-    `, userID, materialID)
+    `, userID, materialID, time.Now().Format("2006-01-02 15:04:05"))
 	if err != nil {
 		return fmt.Errorf("%s exec: %w", op, err)
 	}
@@ -172,7 +313,7 @@ func (s *Storage) MarkMaterialAsNotCompleted(userID, materialID string) error {
 	_, err := s.pool.Exec(ctx, `
 WITH upsert AS (
     UPDATE user_materials
-    SET completed = false
+    SET completed = false, completed_at = NULL
     WHERE user_id = $1 AND material_id = $2
     RETURNING *
 )
@@ -187,30 +328,56 @@ WHERE NOT EXISTS (SELECT * FROM upsert);
 	return nil
 }
 
-func (s *Storage) SearchMaterials(query string) ([]models.Material, error) {
+func (s *Storage) SearchMaterials(search models.SearchQuery, userID string) (models.MaterialList, error) {
 	const op = "storage.postgresql.SearchMaterials"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	rows, err := s.pool.Query(ctx, "SELECT * FROM materials WHERE name LIKE '%'||$1||'%' OR description LIKE '%'||$1||'%'", query)
+	// Materials have no rating, a search sorted by it lists them by relevance.
+	page := search.Page
+	if _, ok := materialSearchOrders[page.Sort]; !ok {
+		page.Sort = ""
+	}
+	p, err := newPager(materialSearchOrders, page)
+	if err != nil {
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{}
+	user := args.add(userID)
+	text := args.add(search.Query)
+	where := materialSearchFilter(search, userID, text, &args)
+	query, err := p.query("SELECT "+materialColumns+", "+
+		materialCompleted(user)+" AS completed, "+
+		materialCollections(user)+" AS collections, "+
+		searchRank("materials", text)+" AS rank, "+
+		searchHighlight("materials", text)+" AS highlight "+
+		"FROM materials WHERE "+where, &args)
+	if err != nil {
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var materials []models.Material
+	materials := []models.Material{}
 	for rows.Next() {
 		var material models.Material
-		if err := rows.Scan(&material.Id, &material.CreatedAt, &material.UpdatedAt, &material.UserId, &material.Name, &material.Description, &material.TypeId, &material.Xp, &material.Link); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+		if err := scanMaterial(rows, &material, &material.Completed, &material.Collections, &material.Rank, &material.Highlight, p.key()); err != nil {
+			return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
 		}
+		material.Highlight = highlightHTML(material.Highlight)
 		materials = append(materials, material)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.MaterialList{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return materials, nil
+	n, cursor := p.next(len(materials))
+	return models.MaterialList{Materials: materials[:n], NextCursor: cursor}, nil
 }
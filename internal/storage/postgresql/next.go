@@ -0,0 +1,65 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// GetNextCandidates returns the incomplete materials of the collections the user has joined,
+// grouped by collection and in each collection's section and material order.
+func (s *Storage) GetNextCandidates(userID string) ([]models.NextCandidate, error) {
+	const op = "storage.postgresql.GetNextCandidates"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        WITH progress AS (
+            SELECT collection_materials.collection_id,
+                   SUM(materials.xp) AS sum_xp,
+                   COALESCE(SUM(materials.xp) FILTER (WHERE user_materials.completed), 0) AS completed_xp,
+                   MAX(user_materials.completed_at) FILTER (WHERE user_materials.completed) AS last_activity
+            FROM user_collections
+            INNER JOIN collection_materials ON collection_materials.collection_id = user_collections.collection_id
+            INNER JOIN materials ON materials.id = collection_materials.material_id
+            LEFT JOIN user_materials ON user_materials.material_id = materials.id AND user_materials.user_id = $1
+            WHERE user_collections.user_id = $1
+            GROUP BY collection_materials.collection_id
+        )
+        SELECT collections.id, collections.name, progress.sum_xp, progress.completed_xp, progress.last_activity,
+               materials.id, materials.name, COALESCE(materials.link, ''), COALESCE(materials.type_id::text, ''),
+               COALESCE(type_materials.name, ''), COALESCE(type_materials.xp, 0), materials.xp
+        FROM user_collections
+        INNER JOIN collections ON collections.id = user_collections.collection_id
+        INNER JOIN progress ON progress.collection_id = collections.id
+        INNER JOIN collection_materials ON collection_materials.collection_id = collections.id
+        INNER JOIN materials ON materials.id = collection_materials.material_id
+        LEFT JOIN type_materials ON type_materials.id = materials.type_id
+        LEFT JOIN collection_sections ON collection_sections.id = collection_materials.section_id
+        WHERE user_collections.user_id = $1 AND NOT `+materialCompleted(1)+`
+        ORDER BY collections.id, collection_sections.position NULLS LAST, collection_materials.position NULLS LAST,
+                 materials.created_at, materials.id;
+    `, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	candidates := []models.NextCandidate{}
+	for rows.Next() {
+		var c models.NextCandidate
+		if err = rows.Scan(&c.CollectionId, &c.CollectionName, &c.CollectionXp, &c.CompletedXp, &c.LastActivity,
+			&c.MaterialId, &c.Name, &c.Link, &c.TypeId, &c.TypeName, &c.TypeXp, &c.Xp); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return candidates, nil
+}
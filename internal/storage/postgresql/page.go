@@ -0,0 +1,119 @@
+package postgresql
+
+import (
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/grafchitaru/skillBuilder/internal/pagination"
+	"slices"
+	"strings"
+)
+
+// sortKey is an output column of a paged query and the type its cursor value is cast back to.
+type sortKey struct {
+	column string
+	cast   string
+}
+
+// listOrder is a keyset order over keys, the row id breaking ties.
+type listOrder struct {
+	keys []sortKey
+	desc bool
+}
+
+var (
+	createdAtKey = sortKey{"created_at", "timestamp"}
+	updatedAtKey = sortKey{"updated_at", "timestamp"}
+	nameKey      = sortKey{"name", "text"}
+)
+
+var collectionListOrders = map[string]listOrder{
+	"":                   {keys: []sortKey{createdAtKey}},
+	models.SortCreatedAt: {keys: []sortKey{createdAtKey}, desc: true},
+	models.SortUpdatedAt: {keys: []sortKey{updatedAtKey}, desc: true},
+	models.SortName:      {keys: []sortKey{nameKey}},
+	models.SortXp:        {keys: []sortKey{{"sum_xp", "bigint"}}, desc: true},
+	models.SortRating:    {keys: []sortKey{{"rating", "float8"}, {"rating_count", "bigint"}}, desc: true},
+}
+
+var materialListOrders = map[string]listOrder{
+	"":                   {keys: []sortKey{createdAtKey}},
+	models.SortCreatedAt: {keys: []sortKey{createdAtKey}, desc: true},
+	models.SortUpdatedAt: {keys: []sortKey{updatedAtKey}, desc: true},
+	models.SortName:      {keys: []sortKey{nameKey}},
+	models.SortXp:        {keys: []sortKey{{"xp", "integer"}}, desc: true},
+}
+
+// pageCursor is the position after the last row of a page.
+type pageCursor struct {
+	Sort string   `json:"s"`
+	Keys []string `json:"k"`
+}
+
+// pager fetches a list one keyset page at a time.
+type pager struct {
+	page  models.Page
+	order listOrder
+	limit int
+	keys  [][]string
+}
+
+func newPager(orders map[string]listOrder, page models.Page) (*pager, error) {
+	order, ok := orders[page.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort %q", page.Sort)
+	}
+	return &pager{page: page, order: order, limit: pagination.Limit(page.Limit)}, nil
+}
+
+// query wraps query, whose output has the sort key columns and id, into the requested page.
+// Every row ends with a text[] of its keys, to be scanned into key.
+func (p *pager) query(query string, args *queryArgs) (string, error) {
+	keys := append(slices.Clone(p.order.keys), sortKey{"id", "uuid"})
+
+	compare, direction := ">", ""
+	if p.order.desc {
+		compare, direction = "<", " DESC"
+	}
+
+	columns := make([]string, len(keys))
+	texts := make([]string, len(keys))
+	orderBy := make([]string, len(keys))
+	for i, key := range keys {
+		columns[i] = "page." + key.column
+		texts[i] = columns[i] + "::text"
+		orderBy[i] = columns[i] + direction
+	}
+
+	where := ""
+	if p.page.Cursor != "" {
+		var cursor pageCursor
+		if err := pagination.Decode(p.page.Cursor, &cursor); err != nil || cursor.Sort != p.page.Sort || len(cursor.Keys) != len(keys) {
+			return "", pagination.ErrInvalidCursor
+		}
+		params := make([]string, len(keys))
+		for i, key := range keys {
+			params[i] = fmt.Sprintf("$%d::%s", args.add(cursor.Keys[i]), key.cast)
+		}
+		where = fmt.Sprintf("WHERE (%s) %s (%s)", strings.Join(columns, ", "), compare, strings.Join(params, ", "))
+	}
+
+	// One extra row tells whether there is a next page.
+	limit := args.add(p.limit + 1)
+
+	return fmt.Sprintf(`SELECT page.*, ARRAY[%s] FROM (%s) AS page %s ORDER BY %s LIMIT $%d`,
+		strings.Join(texts, ", "), query, where, strings.Join(orderBy, ", "), limit), nil
+}
+
+// key returns the scan destination for the keys of the next row.
+func (p *pager) key() *[]string {
+	p.keys = append(p.keys, nil)
+	return &p.keys[len(p.keys)-1]
+}
+
+// next returns how many of the n scanned rows belong to the page and the cursor of the following one.
+func (p *pager) next(n int) (int, string) {
+	if n <= p.limit {
+		return n, ""
+	}
+	return p.limit, pagination.Encode(pageCursor{Sort: p.page.Sort, Keys: p.keys[p.limit-1]})
+}
@@ -0,0 +1,48 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/v5/stdlib" // pgx
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+// newTestStorage migrates and connects to the database of TEST_DATABASE_DSN. Tests that need
+// a database are skipped when it isn't set.
+func newTestStorage(t *testing.T) *Storage {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, goose.SetDialect("postgres"))
+	require.NoError(t, goose.Up(db, "../../../migrations", goose.WithAllowMissing()))
+
+	s, err := New(dsn)
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestUser(t *testing.T, s *Storage) string {
+	id, err := s.Registration(uuid.NewString(), "test-"+uuid.NewString(), "password")
+	require.NoError(t, err)
+	return id
+}
+
+func newTestMaterial(t *testing.T, s *Storage, userID, collectionID string) string {
+	var typeID string
+	require.NoError(t, s.pool.QueryRow(context.Background(), "SELECT id FROM type_materials LIMIT 1").Scan(&typeID))
+
+	materialID, err := s.CreateMaterial(userID, "Tour of Go", "", typeID, 10, "https://go.dev/tour")
+	require.NoError(t, err)
+	require.NoError(t, s.AddMaterialToCollection(collectionID, materialID))
+	return materialID
+}
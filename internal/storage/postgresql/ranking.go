@@ -0,0 +1,147 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// Weights of a collection's activity in its ranking score.
+const (
+	rankingJoinWeight       = 5
+	rankingCompletionWeight = 2
+	rankingXpWeight         = 0.1
+)
+
+var rankingListOrders = map[string]listOrder{
+	"": {keys: []sortKey{{"score", "float8"}}, desc: true},
+}
+
+// RefreshCollectionRanking recomputes a ranking of public collections by how many users joined
+// them, how many of their materials were completed and how much XP that earned since the given
+// time, or ever when since is zero. It returns how many collections were ranked.
+// A completion counts only for collections its user joined, forks share the original's
+// materials and would otherwise be credited with the original's completions.
+func (s *Storage) RefreshCollectionRanking(kind string, since time.Time) (int, error) {
+	const op = "storage.postgresql.RefreshCollectionRanking"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	var from *string
+	if !since.IsZero() {
+		value := since.Format("2006-01-02 15:04:05")
+		from = &value
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `DELETE FROM collection_rankings WHERE kind=$1;`, kind); err != nil {
+		return 0, fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+        INSERT INTO collection_rankings(collection_id, kind, score, joins, completions, xp_earned, computed_at)
+        SELECT collections.id, $1,
+               COALESCE(joins.n, 0) * $4::float8 + COALESCE(completions.n, 0) * $5::float8 + COALESCE(completions.xp, 0) * $6::float8,
+               COALESCE(joins.n, 0), COALESCE(completions.n, 0), COALESCE(completions.xp, 0), $3
+        FROM collections
+        LEFT JOIN (
+            SELECT collection_id, COUNT(*) AS n
+            FROM user_collections
+            WHERE $2::timestamp IS NULL OR user_collections.joined_at >= $2::timestamp
+            GROUP BY collection_id
+        ) AS joins ON joins.collection_id = collections.id
+        LEFT JOIN (
+            SELECT collection_materials.collection_id, COUNT(*) AS n, SUM(materials.xp) AS xp
+            FROM user_materials
+            INNER JOIN materials ON materials.id = user_materials.material_id
+            INNER JOIN collection_materials ON collection_materials.material_id = materials.id
+            INNER JOIN user_collections ON user_collections.collection_id = collection_materials.collection_id
+                AND user_collections.user_id = user_materials.user_id
+            WHERE user_materials.completed
+              AND ($2::timestamp IS NULL OR user_materials.completed_at >= $2::timestamp)
+            GROUP BY collection_materials.collection_id
+        ) AS completions ON completions.collection_id = collections.id
+        WHERE collections.is_public AND (joins.n > 0 OR completions.n > 0);
+    `, kind, from, time.Now().Format("2006-01-02 15:04:05"), rankingJoinWeight, rankingCompletionWeight, rankingXpWeight)
+	if err != nil {
+		return 0, fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// GetRankedCollections returns a page of the ranking's collections, highest score first.
+// Collections made private since the ranking was computed are left out.
+func (s *Storage) GetRankedCollections(kind, userID string, page models.Page) (models.RankedCollectionList, error) {
+	const op = "storage.postgresql.GetRankedCollections"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p, err := newPager(rankingListOrders, page)
+	if err != nil {
+		return models.RankedCollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	args := queryArgs{userID, kind}
+	query, err := p.query(`
+	SELECT
+		`+collectionColumns+`,
+		COALESCE(
+			(SELECT SUM(materials.xp)
+			 FROM materials
+			 JOIN collection_materials ON materials.id = collection_materials.material_id
+			 WHERE collection_materials.collection_id = collections.id), 0
+		) AS sum_xp,
+		COALESCE(
+			(SELECT SUM(materials.xp)
+			 FROM materials
+			 JOIN collection_materials ON materials.id = collection_materials.material_id
+			 JOIN user_materials ON materials.id = user_materials.material_id
+			 WHERE collection_materials.collection_id = collections.id
+			   AND user_materials.completed = true
+			   AND user_materials.user_id = $1), 0
+		) AS xp,
+		collection_rankings.score, collection_rankings.joins, collection_rankings.completions, collection_rankings.xp_earned
+	FROM
+		collections
+	INNER JOIN collection_rankings ON collection_rankings.collection_id = collections.id AND collection_rankings.kind = $2
+	WHERE collections.is_public`, &args)
+	if err != nil {
+		return models.RankedCollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return models.RankedCollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	collections := []models.RankedCollection{}
+	for rows.Next() {
+		var ranked models.RankedCollection
+		if err = scanCollection(rows, &ranked.Collection, &ranked.Score, &ranked.Joins, &ranked.Completions,
+			&ranked.XpEarned, p.key()); err != nil {
+			return models.RankedCollectionList{}, fmt.Errorf("%s: %w", op, err)
+		}
+		collections = append(collections, ranked)
+	}
+
+	if err = rows.Err(); err != nil {
+		return models.RankedCollectionList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, cursor := p.next(len(collections))
+	return models.RankedCollectionList{Collections: collections[:n], NextCursor: cursor}, nil
+}
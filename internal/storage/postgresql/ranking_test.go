@@ -0,0 +1,47 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRefreshCollectionRanking_Fork(t *testing.T) {
+	s := newTestStorage(t)
+	author := newTestUser(t, s)
+	learner := newTestUser(t, s)
+
+	collectionID, err := s.CreateCollection(author, "Go", "")
+	require.NoError(t, err)
+	require.NoError(t, s.SetCollectionVisibility(collectionID, true))
+	materialID := newTestMaterial(t, s, author, collectionID)
+
+	forkID, err := s.ForkCollection(author, collectionID, true)
+	require.NoError(t, err)
+	require.NoError(t, s.SetCollectionVisibility(forkID, true))
+
+	require.NoError(t, s.AddCollectionToUser(learner, collectionID))
+	require.NoError(t, s.MarkMaterialAsCompleted(learner, materialID))
+
+	// A kind of its own keeps the test apart from the rankings of other tests.
+	kind := "test-" + uuid.NewString()
+	_, err = s.RefreshCollectionRanking(kind, time.Time{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		s.pool.Exec(context.Background(), "DELETE FROM collection_rankings WHERE kind=$1", kind)
+	})
+
+	completions := func(id string) int {
+		var n int
+		err := s.pool.QueryRow(context.Background(), `
+            SELECT COALESCE((SELECT completions FROM collection_rankings WHERE kind=$1 AND collection_id=$2), 0);
+        `, kind, id).Scan(&n)
+		require.NoError(t, err)
+		return n
+	}
+	assert.Equal(t, 1, completions(collectionID))
+	assert.Equal(t, 0, completions(forkID))
+}
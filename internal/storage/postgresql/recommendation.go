@@ -0,0 +1,92 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// Caps on the corpus loaded for recommendations, keeping the most recent items.
+const (
+	maxRecommendationCompleted  = 200
+	maxRecommendationCandidates = 2000
+)
+
+// GetRecommendationCorpus loads the user's completed materials and the candidates to
+// recommend: public collections the user neither owns nor has joined, and materials of public
+// collections the user neither owns nor has completed.
+func (s *Storage) GetRecommendationCorpus(userID string) (models.RecommendationCorpus, error) {
+	const op = "storage.postgresql.GetRecommendationCorpus"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	corpus := models.RecommendationCorpus{}
+	var err error
+
+	corpus.Completed, err = s.queryRecommendationItems(ctx, models.RecommendMaterial, `
+        SELECT materials.id, materials.name, COALESCE(materials.description, ''), `+materialTagNames+`
+        FROM materials
+        INNER JOIN user_materials ON user_materials.material_id = materials.id
+        WHERE user_materials.user_id = $1 AND user_materials.completed
+        ORDER BY user_materials.completed_at DESC NULLS LAST, materials.id
+        LIMIT $2;
+    `, userID, maxRecommendationCompleted)
+	if err != nil {
+		return corpus, fmt.Errorf("%s: %w", op, err)
+	}
+
+	corpus.Collections, err = s.queryRecommendationItems(ctx, models.RecommendCollection, `
+        SELECT collections.id, collections.name, COALESCE(collections.description, ''),
+               COALESCE((SELECT array_agg(tags.name ORDER BY tags.name)
+                   FROM collection_tags INNER JOIN tags ON tags.id = collection_tags.tag_id
+                   WHERE collection_tags.collection_id = collections.id), '{}')
+        FROM collections
+        WHERE collections.is_public AND collections.user_id <> $1 AND NOT `+collectionJoined(1)+`
+        ORDER BY collections.created_at DESC, collections.id
+        LIMIT $2;
+    `, userID, maxRecommendationCandidates)
+	if err != nil {
+		return corpus, fmt.Errorf("%s: %w", op, err)
+	}
+
+	corpus.Materials, err = s.queryRecommendationItems(ctx, models.RecommendMaterial, `
+        SELECT materials.id, materials.name, COALESCE(materials.description, ''), `+materialTagNames+`
+        FROM materials
+        WHERE materials.user_id <> $1 AND NOT `+materialCompleted(1)+`
+          AND EXISTS (SELECT 1 FROM collection_materials
+              INNER JOIN collections ON collections.id = collection_materials.collection_id
+              WHERE collection_materials.material_id = materials.id AND collections.is_public)
+        ORDER BY materials.created_at DESC, materials.id
+        LIMIT $2;
+    `, userID, maxRecommendationCandidates)
+	if err != nil {
+		return corpus, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return corpus, nil
+}
+
+const materialTagNames = `COALESCE((SELECT array_agg(tags.name ORDER BY tags.name)
+	FROM material_tags INNER JOIN tags ON tags.id = material_tags.tag_id
+	WHERE material_tags.material_id = materials.id), '{}')`
+
+func (s *Storage) queryRecommendationItems(ctx context.Context, kind, sql string, args ...any) ([]models.RecommendationItem, error) {
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.RecommendationItem{}
+	for rows.Next() {
+		item := models.RecommendationItem{Kind: kind}
+		if err = rows.Scan(&item.Id, &item.Name, &item.Description, &item.Tags); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
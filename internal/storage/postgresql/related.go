@@ -0,0 +1,122 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// GetCollectionEngagement returns, for every collection a user has joined and completed some of,
+// the share of its materials the user has completed.
+func (s *Storage) GetCollectionEngagement() ([]models.Engagement, error) {
+	const op = "storage.postgresql.GetCollectionEngagement"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT user_collections.user_id, user_collections.collection_id,
+               (COUNT(*) FILTER (WHERE user_materials.completed))::float8 / COUNT(*)
+        FROM user_collections
+        INNER JOIN collection_materials ON collection_materials.collection_id = user_collections.collection_id
+        LEFT JOIN user_materials ON user_materials.material_id = collection_materials.material_id
+            AND user_materials.user_id = user_collections.user_id
+        GROUP BY user_collections.user_id, user_collections.collection_id
+        HAVING COUNT(*) FILTER (WHERE user_materials.completed) > 0;
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	engagement := []models.Engagement{}
+	for rows.Next() {
+		var e models.Engagement
+		if err = rows.Scan(&e.UserId, &e.CollectionId, &e.Progress); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		engagement = append(engagement, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return engagement, nil
+}
+
+// ReplaceRelatedCollections swaps the precomputed related collections for a new set at once.
+func (s *Storage) ReplaceRelatedCollections(similarities []models.CollectionSimilarity) error {
+	const op = "storage.postgresql.ReplaceRelatedCollections"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `DELETE FROM related_collections;`); err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	now := time.Now()
+	for _, similarity := range similarities {
+		// Collections deleted since engagement was read are skipped rather than failing the batch.
+		_, err = tx.Exec(ctx, `
+            INSERT INTO related_collections(collection_id, related_id, score, common_users, computed_at)
+            SELECT $1, $2, $3, $4, $5
+            WHERE EXISTS (SELECT 1 FROM collections WHERE id = $1)
+              AND EXISTS (SELECT 1 FROM collections WHERE id = $2);
+        `, similarity.CollectionId, similarity.RelatedId, similarity.Score, similarity.CommonUsers, now.Format("2006-01-02 15:04:05"))
+		if err != nil {
+			return fmt.Errorf("%s exec: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetRelatedCollections returns up to limit collections related to the collection that are
+// visible to the user, most related first.
+func (s *Storage) GetRelatedCollections(collectionID, userID string, limit int) ([]models.RelatedCollection, error) {
+	const op = "storage.postgresql.GetRelatedCollections"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT collections.id, collections.name, related_collections.score, related_collections.common_users
+        FROM related_collections
+        INNER JOIN collections ON collections.id = related_collections.related_id
+        WHERE related_collections.collection_id = $1 AND `+collectionVisible(2)+`
+        ORDER BY related_collections.score DESC, collections.id
+        LIMIT $3;
+    `, collectionID, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	related := []models.RelatedCollection{}
+	for rows.Next() {
+		var collection models.RelatedCollection
+		if err = rows.Scan(&collection.Id, &collection.Name, &collection.Score, &collection.CommonUsers); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		related = append(related, collection)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return related, nil
+}
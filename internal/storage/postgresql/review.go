@@ -0,0 +1,89 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+func (s *Storage) SaveReview(collectionID, userID string, rating int, text string) error {
+	const op = "storage.postgresql.SaveReview"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	if err := s.checkCollectionVisible(ctx, collectionID, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	_, err := s.pool.Exec(ctx, `
+        INSERT INTO collection_reviews(collection_id, user_id, created_at, updated_at, rating, text)
+        VALUES($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (collection_id, user_id)
+        DO UPDATE SET rating = EXCLUDED.rating, text = EXCLUDED.text, updated_at = EXCLUDED.updated_at;
+    `, collectionID, userID, now.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), rating, text)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteReview(collectionID, userID string) error {
+	const op = "storage.postgresql.DeleteReview"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err := s.pool.Exec(ctx, `
+        DELETE FROM collection_reviews
+        WHERE collection_id=$1 AND user_id=$2;
+    `, collectionID, userID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetReviews(collectionID, userID string) ([]models.Review, error) {
+	const op = "storage.postgresql.GetReviews"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	if err := s.checkCollectionVisible(ctx, collectionID, userID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT collection_reviews.collection_id, collection_reviews.user_id, users.login,
+               collection_reviews.created_at, collection_reviews.updated_at, collection_reviews.rating, collection_reviews.text
+        FROM collection_reviews
+        INNER JOIN users ON users.id = collection_reviews.user_id
+        WHERE collection_reviews.collection_id = $1
+        ORDER BY collection_reviews.updated_at DESC;
+    `, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var reviews []models.Review
+	for rows.Next() {
+		var review models.Review
+		if err = rows.Scan(&review.CollectionId, &review.UserId, &review.Login, &review.CreatedAt, &review.UpdatedAt, &review.Rating, &review.Text); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,252 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// savedSearchMatchLimit caps notifications created per saved search and kind in one check,
+// the rest is picked up by the following checks.
+const savedSearchMatchLimit = 50
+
+func (s *Storage) CreateSavedSearch(userID, name string, query models.SearchQuery) (string, error) {
+	const op = "storage.postgresql.CreateSavedSearch"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	id := uuid.New()
+	now := time.Now()
+
+	_, err := s.pool.Exec(ctx, `
+        INSERT INTO saved_searches(id, created_at, updated_at, user_id, name, query, checked_at)
+        VALUES($1, $2, $3, $4, $5, $6, $7);
+    `, id, now.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), userID, name, query, now.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return "", fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return id.String(), nil
+}
+
+func (s *Storage) GetSavedSearches(userID string) ([]models.SavedSearch, error) {
+	const op = "storage.postgresql.GetSavedSearches"
+
+	return s.querySavedSearches(op, "WHERE user_id = $1 ORDER BY created_at, id", userID)
+}
+
+func (s *Storage) GetAllSavedSearches() ([]models.SavedSearch, error) {
+	const op = "storage.postgresql.GetAllSavedSearches"
+
+	return s.querySavedSearches(op, "ORDER BY checked_at, id")
+}
+
+func (s *Storage) querySavedSearches(op, where string, args ...any) ([]models.SavedSearch, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT id, created_at, updated_at, user_id, name, query, checked_at
+        FROM saved_searches `+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	searches := []models.SavedSearch{}
+	for rows.Next() {
+		var search models.SavedSearch
+		if err = rows.Scan(&search.Id, &search.CreatedAt, &search.UpdatedAt, &search.UserId, &search.Name, &search.Query, &search.CheckedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		searches = append(searches, search)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return searches, nil
+}
+
+func (s *Storage) DeleteSavedSearch(searchID, userID string) error {
+	const op = "storage.postgresql.DeleteSavedSearch"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err := s.pool.Exec(ctx, `
+        DELETE FROM saved_searches
+        WHERE id=$1 AND user_id=$2;
+    `, searchID, userID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
+
+// CheckSavedSearch notifies the owner of a saved search about public collections and materials
+// of other users that match it and were created after the search was saved.
+func (s *Storage) CheckSavedSearch(search models.SavedSearch) (int, error) {
+	const op = "storage.postgresql.CheckSavedSearch"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	type match struct {
+		kind, id, name string
+	}
+	var matches []match
+
+	args := queryArgs{}
+	from := searchCollectionsFrom(args.add(search.UserId))
+	where := collectionSearchFilter(search.Query, search.UserId, args.add(search.Query.Query), &args)
+	saved := args.add(search.Id)
+	rows, err := s.pool.Query(ctx, `
+        SELECT collections.id, collections.name
+        FROM `+from+`
+        WHERE `+where+` AND collections.is_public AND collections.user_id <> $1
+          AND collections.created_at >= (SELECT created_at FROM saved_searches WHERE id = $`+fmt.Sprint(saved)+`)
+          AND NOT EXISTS (SELECT 1 FROM notifications
+              WHERE notifications.saved_search_id = $`+fmt.Sprint(saved)+` AND notifications.object_id = collections.id)
+        ORDER BY collections.created_at, collections.id
+        LIMIT `+fmt.Sprint(savedSearchMatchLimit), args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s collections: %w", op, err)
+	}
+	for rows.Next() {
+		m := match{kind: "collection"}
+		if err = rows.Scan(&m.id, &m.name); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s collections: %w", op, err)
+		}
+		matches = append(matches, m)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s collections: %w", op, err)
+	}
+
+	args = queryArgs{}
+	owner := args.add(search.UserId)
+	where = materialSearchFilter(search.Query, search.UserId, args.add(search.Query.Query), &args)
+	saved = args.add(search.Id)
+	rows, err = s.pool.Query(ctx, `
+        SELECT materials.id, materials.name
+        FROM materials
+        WHERE `+where+` AND materials.user_id <> $`+fmt.Sprint(owner)+`
+          AND EXISTS (SELECT 1 FROM collection_materials
+              INNER JOIN collections ON collections.id = collection_materials.collection_id
+              WHERE collection_materials.material_id = materials.id AND collections.is_public)
+          AND materials.created_at >= (SELECT created_at FROM saved_searches WHERE id = $`+fmt.Sprint(saved)+`)
+          AND NOT EXISTS (SELECT 1 FROM notifications
+              WHERE notifications.saved_search_id = $`+fmt.Sprint(saved)+` AND notifications.object_id = materials.id)
+        ORDER BY materials.created_at, materials.id
+        LIMIT `+fmt.Sprint(savedSearchMatchLimit), args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s materials: %w", op, err)
+	}
+	for rows.Next() {
+		m := match{kind: "material"}
+		if err = rows.Scan(&m.id, &m.name); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s materials: %w", op, err)
+		}
+		matches = append(matches, m)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s materials: %w", op, err)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	created := 0
+	for _, m := range matches {
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO notifications(id, created_at, user_id, saved_search_id, kind, object_id, name)
+            VALUES($1, $2, $3, $4, $5, $6, $7)
+            ON CONFLICT DO NOTHING;
+        `, uuid.New(), now.Format("2006-01-02 15:04:05"), search.UserId, search.Id, m.kind, m.id, m.name)
+		if err != nil {
+			return 0, fmt.Errorf("%s notify: %w", op, err)
+		}
+		created += int(tag.RowsAffected())
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE saved_searches SET checked_at=$1 WHERE id=$2;
+    `, now.Format("2006-01-02 15:04:05"), search.Id)
+	if err != nil {
+		return 0, fmt.Errorf("%s checked: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return created, nil
+}
+
+func (s *Storage) GetNotifications(userID string, unreadOnly bool) ([]models.Notification, error) {
+	const op = "storage.postgresql.GetNotifications"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT id, created_at, saved_search_id, kind, object_id, name, read_at IS NOT NULL
+        FROM notifications
+        WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+        ORDER BY created_at DESC, id;
+    `, userID, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var notification models.Notification
+		if err = rows.Scan(&notification.Id, &notification.CreatedAt, &notification.SavedSearchId, &notification.Kind,
+			&notification.ObjectId, &notification.Name, &notification.Read); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, nil
+}
+
+func (s *Storage) MarkNotificationRead(notificationID, userID string) error {
+	const op = "storage.postgresql.MarkNotificationRead"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	now := time.Now()
+
+	_, err := s.pool.Exec(ctx, `
+        UPDATE notifications
+        SET read_at = $1
+        WHERE id = $2 AND user_id = $3 AND read_at IS NULL;
+    `, now.Format("2006-01-02 15:04:05"), notificationID, userID)
+	if err != nil {
+		return fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,399 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"html"
+	"strings"
+	"time"
+)
+
+// queryArgs collects positional parameters of a query assembled from optional filters.
+type queryArgs []any
+
+func (args *queryArgs) add(value any) int {
+	*args = append(*args, value)
+	return len(*args)
+}
+
+var rankKey = sortKey{"rank", "float8"}
+
+// Search results are ordered by relevance unless another sort is requested, newer rows first on a tie.
+var collectionSearchOrders = map[string]listOrder{
+	"":                   {keys: []sortKey{rankKey, createdAtKey}, desc: true},
+	models.SortCreatedAt: {keys: []sortKey{createdAtKey}, desc: true},
+	models.SortUpdatedAt: {keys: []sortKey{updatedAtKey}, desc: true},
+	models.SortName:      {keys: []sortKey{nameKey}},
+	models.SortXp:        {keys: []sortKey{{"sum_xp", "bigint"}}, desc: true},
+	models.SortRating:    {keys: []sortKey{{"rating", "float8"}, {"rating_count", "bigint"}}, desc: true},
+}
+
+var materialSearchOrders = map[string]listOrder{
+	"":                   {keys: []sortKey{rankKey, createdAtKey}, desc: true},
+	models.SortCreatedAt: {keys: []sortKey{createdAtKey}, desc: true},
+	models.SortUpdatedAt: {keys: []sortKey{updatedAtKey}, desc: true},
+	models.SortName:      {keys: []sortKey{nameKey}},
+	models.SortXp:        {keys: []sortKey{{"xp", "integer"}}, desc: true},
+}
+
+// xpBuckets are upper XP bounds of the xp facet values, the last one being open-ended.
+var xpBuckets = []struct {
+	value string
+	max   int
+}{
+	{"0-9", 9},
+	{"10-49", 49},
+	{"50-99", 99},
+	{"100+", -1},
+}
+
+// searchCollectionsFrom exposes the total and the user's completed XP of every collection
+// as columns, so search filters and sorting can refer to them.
+func searchCollectionsFrom(user int) string {
+	return fmt.Sprintf(`(
+		SELECT collections.*,
+			COALESCE((SELECT SUM(materials.xp) FROM materials
+				JOIN collection_materials ON materials.id = collection_materials.material_id
+				WHERE collection_materials.collection_id = collections.id), 0) AS sum_xp,
+			COALESCE((SELECT SUM(materials.xp) FROM materials
+				JOIN collection_materials ON materials.id = collection_materials.material_id
+				JOIN user_materials ON materials.id = user_materials.material_id
+				WHERE collection_materials.collection_id = collections.id
+				  AND user_materials.completed = true AND user_materials.user_id = $%d), 0) AS xp
+		FROM collections
+	) AS collections`, user)
+}
+
+func collectionJoined(user int) string {
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM user_collections
+		WHERE user_collections.collection_id = collections.id AND user_collections.user_id = $%d)`, user)
+}
+
+// materialVisible matches the user's own materials and those in a collection visible to the user.
+func materialVisible(user int) string {
+	return fmt.Sprintf(`(materials.user_id = $%d OR EXISTS (SELECT 1 FROM collection_materials
+		INNER JOIN collections ON collections.id = collection_materials.collection_id
+		WHERE collection_materials.material_id = materials.id AND %s))`, user, collectionVisible(user))
+}
+
+// materialCollections lists the collections visible to the user that contain the material.
+func materialCollections(user int) string {
+	return fmt.Sprintf(`COALESCE((SELECT json_agg(json_build_object('id', collections.id, 'name', collections.name) ORDER BY collections.name)
+		FROM collection_materials
+		INNER JOIN collections ON collections.id = collection_materials.collection_id
+		WHERE collection_materials.material_id = materials.id AND %s), '[]')`, collectionVisible(user))
+}
+
+func materialCompleted(user int) string {
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM user_materials
+		WHERE user_materials.material_id = materials.id AND user_materials.user_id = $%d AND user_materials.completed)`, user)
+}
+
+func materialJoined(user int) string {
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM collection_materials
+		INNER JOIN user_collections ON user_collections.collection_id = collection_materials.collection_id
+		WHERE collection_materials.material_id = materials.id AND user_collections.user_id = $%d)`, user)
+}
+
+// collectionSearchFilter applies search to rows of searchCollectionsFrom, the text being parameter $query.
+// A collection is completed once the user has earned all of its XP.
+func collectionSearchFilter(search models.SearchQuery, userID string, query int, args *queryArgs) string {
+	where := []string{collectionVisible(args.add(userID)), searchMatches("collections", query), collectionHasTags(args.add(search.Tags))}
+	if search.TypeId != "" {
+		where = append(where, fmt.Sprintf(`EXISTS (SELECT 1 FROM collection_materials
+			INNER JOIN materials ON materials.id = collection_materials.material_id
+			WHERE collection_materials.collection_id = collections.id AND materials.type_id = $%d)`, args.add(search.TypeId)))
+	}
+	if search.MinXp != nil {
+		where = append(where, fmt.Sprintf("collections.sum_xp >= $%d", args.add(*search.MinXp)))
+	}
+	if search.MaxXp != nil {
+		where = append(where, fmt.Sprintf("collections.sum_xp <= $%d", args.add(*search.MaxXp)))
+	}
+	if search.OwnerId != "" {
+		where = append(where, fmt.Sprintf("collections.user_id = $%d", args.add(search.OwnerId)))
+	}
+	if search.Completed != nil {
+		where = append(where, fmt.Sprintf("(collections.sum_xp > 0 AND collections.xp = collections.sum_xp) = $%d", args.add(*search.Completed)))
+	}
+	if search.Joined != nil {
+		where = append(where, fmt.Sprintf("%s = $%d", collectionJoined(args.add(userID)), args.add(*search.Joined)))
+	}
+	return strings.Join(where, " AND ")
+}
+
+// materialSearchFilter applies search to materials, the text being parameter $query.
+func materialSearchFilter(search models.SearchQuery, userID string, query int, args *queryArgs) string {
+	where := []string{materialVisible(args.add(userID)), searchMatches("materials", query), materialHasTags(args.add(search.Tags))}
+	if search.TypeId != "" {
+		where = append(where, fmt.Sprintf("materials.type_id = $%d", args.add(search.TypeId)))
+	}
+	if search.MinXp != nil {
+		where = append(where, fmt.Sprintf("materials.xp >= $%d", args.add(*search.MinXp)))
+	}
+	if search.MaxXp != nil {
+		where = append(where, fmt.Sprintf("materials.xp <= $%d", args.add(*search.MaxXp)))
+	}
+	if search.OwnerId != "" {
+		where = append(where, fmt.Sprintf("materials.user_id = $%d", args.add(search.OwnerId)))
+	}
+	if search.Completed != nil {
+		where = append(where, fmt.Sprintf("%s = $%d", materialCompleted(args.add(userID)), args.add(*search.Completed)))
+	}
+	if search.Joined != nil {
+		where = append(where, fmt.Sprintf("%s = $%d", materialJoined(args.add(userID)), args.add(*search.Joined)))
+	}
+	return strings.Join(where, " AND ")
+}
+
+// searchTsQuery parses the text parameter $n with both configurations, so Russian and English
+// stems match regardless of which language the query was typed in.
+func searchTsQuery(n int) string {
+	return fmt.Sprintf(`(websearch_to_tsquery('russian', $%[1]d) || websearch_to_tsquery('english', $%[1]d))`, n)
+}
+
+// likeLiteral escapes the LIKE wildcards of the text parameter $n, so "100%" or "snake_case"
+// match literally.
+func likeLiteral(n int) string {
+	return fmt.Sprintf(`replace(replace(replace($%d, '\', '\\'), '%%', '\%%'), '_', '\_')`, n)
+}
+
+// searchMatches matches rows of table against the text parameter $n. The ILIKE fallback keeps
+// partially typed words ("kube") and tokens the parser drops finding something, the trigram
+// word similarity catches misspelled names ("kubernets"). Both ILIKE columns have trigram indexes.
+func searchMatches(table string, n int) string {
+	return fmt.Sprintf(`($%[2]d = '' OR %[1]s.search_vector @@ %[3]s
+		OR %[1]s.name ILIKE '%%' || %[4]s || '%%' OR %[1]s.description ILIKE '%%' || %[4]s || '%%'
+		OR $%[2]d <%% %[1]s.name)`, table, n, searchTsQuery(n), likeLiteral(n))
+}
+
+func searchRank(table string, n int) string {
+	return fmt.Sprintf(`(ts_rank(%[1]s.search_vector, %[3]s) + word_similarity($%[2]d, %[1]s.name))::float8`, table, n, searchTsQuery(n))
+}
+
+// highlightStart and highlightStop delimit matched words in ts_headline output, chr(2) and chr(3)
+// in searchHighlight. They become <mark> tags only after the text around them is HTML-escaped.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+var highlightMarks = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+func searchHighlight(table string, n int) string {
+	return fmt.Sprintf(`CASE WHEN $%[2]d = '' THEN '' ELSE ts_headline('russian', %[1]s.name || '. ' || COALESCE(%[1]s.description, ''), %[3]s,
+		'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxWords=35, MinWords=15, MaxFragments=2') END`, table, n, searchTsQuery(n))
+}
+
+// highlightHTML turns a searchHighlight fragment into HTML. Names and descriptions are user
+// input, so everything but the highlight marks is escaped.
+func highlightHTML(headline string) string {
+	return highlightMarks.Replace(html.EscapeString(headline))
+}
+
+func (s *Storage) Suggest(query, userID string, limit int) (models.Suggestions, error) {
+	const op = "storage.postgresql.Suggest"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	suggestions := models.Suggestions{Titles: []models.Suggestion{}, Tags: []string{}}
+
+	// Prefix matches go first, then whatever is closest by trigram similarity.
+	rows, err := s.pool.Query(ctx, `
+        SELECT type, id, name
+        FROM (
+            SELECT 'collection' AS type, collections.id, collections.name
+            FROM collections
+            WHERE (collections.name ILIKE '%' || `+likeLiteral(1)+` || '%' OR $1 <% collections.name) AND `+collectionVisible(3)+`
+            UNION ALL
+            SELECT 'material' AS type, materials.id, materials.name
+            FROM materials
+            WHERE (materials.name ILIKE '%' || `+likeLiteral(1)+` || '%' OR $1 <% materials.name) AND `+materialVisible(3)+`
+        ) AS titles
+        ORDER BY name ILIKE `+likeLiteral(1)+` || '%' DESC, word_similarity($1, name) DESC, name
+        LIMIT $2;
+    `, query, limit, userID)
+	if err != nil {
+		return models.Suggestions{}, fmt.Errorf("%s titles: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var suggestion models.Suggestion
+		if err = rows.Scan(&suggestion.Type, &suggestion.Id, &suggestion.Name); err != nil {
+			return models.Suggestions{}, fmt.Errorf("%s titles: %w", op, err)
+		}
+		suggestions.Titles = append(suggestions.Titles, suggestion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return models.Suggestions{}, fmt.Errorf("%s titles: %w", op, err)
+	}
+
+	rows, err = s.pool.Query(ctx, `
+        SELECT tags.name
+        FROM tags
+        WHERE tags.name ILIKE `+likeLiteral(1)+` || '%' OR $1 <% tags.name
+        ORDER BY tags.name ILIKE `+likeLiteral(1)+` || '%' DESC,
+                 (SELECT count(*) FROM collection_tags WHERE collection_tags.tag_id = tags.id) +
+                 (SELECT count(*) FROM material_tags WHERE material_tags.tag_id = tags.id) DESC,
+                 tags.name
+        LIMIT $2;
+    `, query, limit)
+	if err != nil {
+		return models.Suggestions{}, fmt.Errorf("%s tags: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return models.Suggestions{}, fmt.Errorf("%s tags: %w", op, err)
+		}
+		suggestions.Tags = append(suggestions.Tags, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return models.Suggestions{}, fmt.Errorf("%s tags: %w", op, err)
+	}
+
+	return suggestions, nil
+}
+
+// SearchFacets counts search results per filter value. Counts are taken over the whole
+// filtered result set, so they describe how the current results split further.
+func (s *Storage) SearchFacets(search models.SearchQuery, userID string) (models.SearchFacets, error) {
+	const op = "storage.postgresql.SearchFacets"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	facets := models.SearchFacets{}
+
+	var err error
+	args := queryArgs{}
+	where := materialSearchFilter(search, userID, args.add(search.Query), &args)
+	facets.Types, err = s.queryFacet(ctx, `
+        SELECT type_materials.id::text, type_materials.name, count(*)
+        FROM materials
+        INNER JOIN type_materials ON type_materials.id = materials.type_id
+        WHERE `+where+`
+        GROUP BY type_materials.id, type_materials.name
+        ORDER BY count(*) DESC, type_materials.name;
+    `, args...)
+	if err != nil {
+		return models.SearchFacets{}, fmt.Errorf("%s types: %w", op, err)
+	}
+
+	cases := make([]string, 0, len(xpBuckets))
+	for _, bucket := range xpBuckets {
+		if bucket.max < 0 {
+			cases = append(cases, fmt.Sprintf("ELSE '%s'", bucket.value))
+			continue
+		}
+		cases = append(cases, fmt.Sprintf("WHEN materials.xp <= %d THEN '%s'", bucket.max, bucket.value))
+	}
+	xp, err := s.queryFacet(ctx, `
+        SELECT CASE `+strings.Join(cases, " ")+` END AS bucket, '', count(*)
+        FROM materials
+        WHERE `+where+`
+        GROUP BY bucket;
+    `, args...)
+	if err != nil {
+		return models.SearchFacets{}, fmt.Errorf("%s xp: %w", op, err)
+	}
+	facets.Xp = []models.FacetCount{}
+	for _, bucket := range xpBuckets {
+		for _, count := range xp {
+			if count.Value == bucket.value {
+				facets.Xp = append(facets.Xp, count)
+			}
+		}
+	}
+
+	completedArgs := append(queryArgs{}, args...)
+	completed := materialCompleted(completedArgs.add(userID))
+	facets.Completed, err = s.queryFacet(ctx, `
+        SELECT `+completed+`::text, '', count(*)
+        FROM materials
+        WHERE `+where+`
+        GROUP BY 1
+        ORDER BY 1 DESC;
+    `, completedArgs...)
+	if err != nil {
+		return models.SearchFacets{}, fmt.Errorf("%s completed: %w", op, err)
+	}
+
+	args = queryArgs{}
+	from := searchCollectionsFrom(args.add(userID))
+	where = collectionSearchFilter(search, userID, args.add(search.Query), &args)
+	facets.Joined, err = s.queryFacet(ctx, `
+        SELECT `+collectionJoined(1)+`::text, '', count(*)
+        FROM `+from+`
+        WHERE `+where+`
+        GROUP BY 1
+        ORDER BY 1 DESC;
+    `, args...)
+	if err != nil {
+		return models.SearchFacets{}, fmt.Errorf("%s joined: %w", op, err)
+	}
+
+	// Tags and owners are shared by collections and materials.
+	materialWhere := materialSearchFilter(search, userID, 2, &args)
+	matched := `
+        SELECT collections.id, collections.user_id, 'collection' AS kind FROM ` + from + ` WHERE ` + where + `
+        UNION ALL
+        SELECT materials.id, materials.user_id, 'material' AS kind FROM materials WHERE ` + materialWhere
+
+	facets.Tags, err = s.queryFacet(ctx, `
+        SELECT tags.name, '', count(*)
+        FROM (`+matched+`) AS matched
+        INNER JOIN (
+            SELECT collection_id AS id, tag_id, 'collection' AS kind FROM collection_tags
+            UNION ALL
+            SELECT material_id AS id, tag_id, 'material' AS kind FROM material_tags
+        ) AS tagged ON tagged.id = matched.id AND tagged.kind = matched.kind
+        INNER JOIN tags ON tags.id = tagged.tag_id
+        GROUP BY tags.name
+        ORDER BY count(*) DESC, tags.name
+        LIMIT 20;
+    `, args...)
+	if err != nil {
+		return models.SearchFacets{}, fmt.Errorf("%s tags: %w", op, err)
+	}
+
+	facets.Owners, err = s.queryFacet(ctx, `
+        SELECT users.id::text, users.login, count(*)
+        FROM (`+matched+`) AS matched
+        INNER JOIN users ON users.id = matched.user_id
+        GROUP BY users.id, users.login
+        ORDER BY count(*) DESC, users.login
+        LIMIT 10;
+    `, args...)
+	if err != nil {
+		return models.SearchFacets{}, fmt.Errorf("%s owners: %w", op, err)
+	}
+
+	return facets, nil
+}
+
+func (s *Storage) queryFacet(ctx context.Context, sql string, args ...any) ([]models.FacetCount, error) {
+	rows, err := s.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []models.FacetCount{}
+	for rows.Next() {
+		var count models.FacetCount
+		if err = rows.Scan(&count.Value, &count.Label, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
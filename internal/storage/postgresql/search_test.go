@@ -0,0 +1,45 @@
+package postgresql
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHighlightHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		headline string
+		want     string
+	}{
+		{
+			name:     "marks",
+			headline: "Learn \x02Go\x03 fast",
+			want:     "Learn <mark>Go</mark> fast",
+		},
+		{
+			name:     "script in name",
+			headline: "<script>alert(1)</script> \x02Go\x03. Tips & tricks",
+			want:     "&lt;script&gt;alert(1)&lt;/script&gt; <mark>Go</mark>. Tips &amp; tricks",
+		},
+		{
+			name:     "attribute",
+			headline: "<img src=x onerror=\"alert('\x02go\x03')\">",
+			want:     "&lt;img src=x onerror=&#34;alert(&#39;<mark>go</mark>&#39;)&#34;&gt;",
+		},
+		{
+			name:     "empty",
+			headline: "",
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, highlightHTML(tt.headline))
+		})
+	}
+}
+
+func TestLikeLiteral(t *testing.T) {
+	assert.Equal(t, `replace(replace(replace($3, '\', '\\'), '%', '\%'), '_', '\_')`, likeLiteral(3))
+}
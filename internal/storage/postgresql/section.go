@@ -0,0 +1,113 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// ImportSection appends a section to the collection and creates its materials in order.
+func (s *Storage) ImportSection(collectionID, userID, name string, materials []models.NewMaterial) (string, []string, error) {
+	const op = "storage.postgresql.ImportSection"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	sectionID := uuid.New().String()
+	now := time.Now()
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO collection_sections(id, collection_id, name, position, created_at, updated_at)
+        SELECT $1, $2, $3, COALESCE(MAX(position), 0) + 1, $4, $4
+        FROM collection_sections
+        WHERE collection_id = $2;
+    `, sectionID, collectionID, name, now.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return "", nil, fmt.Errorf("%s exec: %w", op, err)
+	}
+
+	// Materials continue after those already in the collection, so its order runs across sections.
+	var last int
+	err = tx.QueryRow(ctx, `
+        SELECT COALESCE(MAX(position), 0) FROM collection_materials WHERE collection_id = $1;
+    `, collectionID).Scan(&last)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s query: %w", op, err)
+	}
+
+	ids := make([]string, 0, len(materials))
+	for i, material := range materials {
+		id, err := createMaterial(ctx, tx, userID, material.Name, material.Description, material.TypeId, material.Xp, material.Link)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s exec: %w", op, err)
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO collection_materials(collection_id, material_id, section_id, position)
+            VALUES($1, $2, $3, $4);
+        `, collectionID, id, sectionID, last+i+1)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s exec: %w", op, err)
+		}
+
+		if len(material.Tags) > 0 {
+			if err = setMaterialTags(ctx, tx, id, material.Tags); err != nil {
+				return "", nil, fmt.Errorf("%s %w", op, err)
+			}
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", nil, fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return sectionID, ids, nil
+}
+
+// GetSections lists the sections of a collection visible to the user, with their material ids in order.
+func (s *Storage) GetSections(collectionID, userID string) ([]models.Section, error) {
+	const op = "storage.postgresql.GetSections"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT collection_sections.id, collection_sections.created_at, collection_sections.name, collection_sections.position,
+               COALESCE((SELECT array_agg(collection_materials.material_id::text ORDER BY collection_materials.position)
+                   FROM collection_materials
+                   WHERE collection_materials.section_id = collection_sections.id), '{}')
+        FROM collection_sections
+        INNER JOIN collections ON collections.id = collection_sections.collection_id
+        WHERE collection_sections.collection_id = $1 AND `+collectionVisible(2)+`
+        ORDER BY collection_sections.position;
+    `, collectionID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	sections := []models.Section{}
+	for rows.Next() {
+		var section models.Section
+		if err := rows.Scan(&section.Id, &section.CreatedAt, &section.Name, &section.Position, &section.Materials); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		sections = append(sections, section)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sections, nil
+}
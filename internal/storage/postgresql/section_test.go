@@ -0,0 +1,38 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestImportSection_Positions(t *testing.T) {
+	s := newTestStorage(t)
+	author := newTestUser(t, s)
+
+	collectionID, err := s.CreateCollection(author, "Go", "")
+	require.NoError(t, err)
+	newTestMaterial(t, s, author, collectionID)
+
+	var typeID string
+	require.NoError(t, s.pool.QueryRow(context.Background(), "SELECT id FROM type_materials LIMIT 1").Scan(&typeID))
+	lessons := []models.NewMaterial{{Name: "Hello", TypeId: typeID}, {Name: "Loops", TypeId: typeID}}
+
+	_, first, err := s.ImportSection(collectionID, author, "Intro", lessons)
+	require.NoError(t, err)
+	_, second, err := s.ImportSection(collectionID, author, "Types", lessons)
+	require.NoError(t, err)
+
+	position := func(materialID string) int {
+		var n int
+		err := s.pool.QueryRow(context.Background(), `
+            SELECT COALESCE(position, 0) FROM collection_materials WHERE collection_id=$1 AND material_id=$2;
+        `, collectionID, materialID).Scan(&n)
+		require.NoError(t, err)
+		return n
+	}
+	// The material added before the sections has no position, the sections continue after it.
+	assert.Equal(t, []int{1, 2, 3, 4}, []int{position(first[0]), position(first[1]), position(second[0]), position(second[1])})
+}
@@ -0,0 +1,169 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+func ensureTag(ctx context.Context, tx pgx.Tx, name string) error {
+	now := time.Now()
+
+	_, err := tx.Exec(ctx, `
+        INSERT INTO tags(id, created_at, updated_at, name)
+        VALUES($1, $2, $3, $4)
+        ON CONFLICT (name) DO NOTHING;
+    `, uuid.New(), now.Format("2006-01-02 15:04:05"), now.Format("2006-01-02 15:04:05"), name)
+
+	return err
+}
+
+func (s *Storage) SetCollectionTags(collectionID string, names []string) error {
+	const op = "storage.postgresql.SetCollectionTags"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setCollectionTags(ctx, tx, collectionID, names); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return nil
+}
+
+// setCollectionTags replaces the tags of a collection within tx.
+func setCollectionTags(ctx context.Context, tx pgx.Tx, collectionID string, names []string) error {
+	_, err := tx.Exec(ctx, `
+        DELETE FROM collection_tags
+        WHERE collection_id=$1;
+    `, collectionID)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+
+	for _, name := range names {
+		if err = ensureTag(ctx, tx, name); err != nil {
+			return fmt.Errorf("tag: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO collection_tags(collection_id, tag_id)
+            SELECT $1, id FROM tags WHERE name=$2
+            ON CONFLICT DO NOTHING;
+        `, collectionID, name)
+		if err != nil {
+			return fmt.Errorf("exec: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) SetMaterialTags(materialID string, names []string) error {
+	const op = "storage.postgresql.SetMaterialTags"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s begin: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = setMaterialTags(ctx, tx, materialID, names); err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s commit: %w", op, err)
+	}
+
+	return nil
+}
+
+// setMaterialTags replaces the tags of a material within tx.
+func setMaterialTags(ctx context.Context, tx pgx.Tx, materialID string, names []string) error {
+	_, err := tx.Exec(ctx, `
+        DELETE FROM material_tags
+        WHERE material_id=$1;
+    `, materialID)
+	if err != nil {
+		return fmt.Errorf("exec: %w", err)
+	}
+
+	for _, name := range names {
+		if err = ensureTag(ctx, tx, name); err != nil {
+			return fmt.Errorf("tag: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO material_tags(material_id, tag_id)
+            SELECT $1, id FROM tags WHERE name=$2
+            ON CONFLICT DO NOTHING;
+        `, materialID, name)
+		if err != nil {
+			return fmt.Errorf("exec: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Storage) GetTags(curatedOnly bool) ([]models.Tag, error) {
+	const op = "storage.postgresql.GetTags"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rows, err := s.pool.Query(ctx, `
+        SELECT id, created_at, updated_at, name, curated, count
+        FROM (
+            SELECT tags.*,
+                   (SELECT count(*) FROM collection_tags
+                    INNER JOIN collections ON collections.id = collection_tags.collection_id
+                    WHERE collection_tags.tag_id = tags.id AND collections.is_public) +
+                   (SELECT count(*) FROM material_tags
+                    WHERE material_tags.tag_id = tags.id AND EXISTS (
+                        SELECT 1 FROM collection_materials
+                        INNER JOIN collections ON collections.id = collection_materials.collection_id
+                        WHERE collection_materials.material_id = material_tags.material_id AND collections.is_public
+                    )) AS count
+            FROM tags
+        ) AS tag_counts
+        WHERE (curated OR count > 0) AND (NOT $1 OR curated)
+        ORDER BY count DESC, name;
+    `, curatedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		var tag models.Tag
+		if err = rows.Scan(&tag.Id, &tag.CreatedAt, &tag.UpdatedAt, &tag.Name, &tag.Curated, &tag.Count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
@@ -7,30 +7,42 @@ import (
 	"time"
 )
 
-func (s *Storage) GetTypeMaterials() ([]models.TypeMaterial, error) {
+func (s *Storage) GetTypeMaterials(page models.Page) (models.TypeMaterialList, error) {
 	const op = "storage.postgresql.GetTypeMaterials"
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	rows, err := s.pool.Query(ctx, "SELECT * FROM type_materials")
+	p, err := newPager(materialListOrders, page)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.TypeMaterialList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var args queryArgs
+	query, err := p.query("SELECT id, created_at, updated_at, name, characteristic, xp FROM type_materials", &args)
+	if err != nil {
+		return models.TypeMaterialList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return models.TypeMaterialList{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var typeMaterials []models.TypeMaterial
+	typeMaterials := []models.TypeMaterial{}
 	for rows.Next() {
 		var typeMaterial models.TypeMaterial
-		if err = rows.Scan(&typeMaterial.Id, &typeMaterial.CreatedAt, &typeMaterial.UpdatedAt, &typeMaterial.Name, &typeMaterial.Characteristic, &typeMaterial.Xp); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+		if err = rows.Scan(&typeMaterial.Id, &typeMaterial.CreatedAt, &typeMaterial.UpdatedAt, &typeMaterial.Name, &typeMaterial.Characteristic, &typeMaterial.Xp, p.key()); err != nil {
+			return models.TypeMaterialList{}, fmt.Errorf("%s: %w", op, err)
 		}
 		typeMaterials = append(typeMaterials, typeMaterial)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return models.TypeMaterialList{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return typeMaterials, nil
+	n, cursor := p.next(len(typeMaterials))
+	return models.TypeMaterialList{TypeMaterials: typeMaterials[:n], NextCursor: cursor}, nil
 }
@@ -1,6 +1,14 @@
 package storage
 
-import "github.com/grafchitaru/skillBuilder/internal/models"
+import (
+	"errors"
+	"github.com/grafchitaru/skillBuilder/internal/models"
+	"time"
+)
+
+// ErrNotFound is returned for collections and materials that don't exist or that the user
+// can't see, so that private ones can't be told apart from missing ones.
+var ErrNotFound = errors.New("not found")
 
 type Repositories interface {
 	Ping() error
@@ -11,24 +19,85 @@ type Repositories interface {
 	Registration(id string, login string, password string) (string, error)
 
 	CreateCollection(userID string, name string, description string) (string, error)
-	DeleteCollection(userID, collectionID string) error
+	DeleteCollection(collectionID string) error
 	UpdateCollection(collection models.Collection) error
-	GetCollections(userID string) ([]models.Collection, error)
-	GetUserCollections(userID string) ([]models.Collection, error)
+	SetCollectionVisibility(collectionID string, isPublic bool) error
+	GetCollections(userID string, filter models.CollectionFilter) (models.CollectionList, error)
+	GetUserCollections(userID string, page models.Page) (models.CollectionList, error)
 	GetCollection(collectionID string, userID string) (models.Collection, error)
 	AddCollectionToUser(userID, collectionID string) error
 	DeleteCollectionFromUser(userID, collectionID string) error
-	SearchCollections(query string, userID string) ([]models.Collection, error)
+	SearchCollections(search models.SearchQuery, userID string) (models.CollectionList, error)
+	ForkCollection(userID, collectionID string, keepProgress bool) (string, error)
+
+	GetCollectionRole(collectionID, userID string) (string, error)
+	GetMaterialRole(materialID, userID string) (string, error)
+	AddCollaborator(collectionID, userID, role string) error
+	DeleteCollaborator(collectionID, userID string) error
+	GetCollaborators(collectionID string) ([]models.Collaborator, error)
 
 	CreateMaterial(userID string, name string, description string, typed string, xp int, link string) (string, error)
 	AddMaterialToCollection(collectionID, materialID string) error
+	RemoveMaterialFromCollection(collectionID, materialID string) error
+	SetMaterialOrder(collectionID string, materialIDs []string) error
+	ImportMaterials(collectionID, userID string, materials []models.NewMaterial) ([]string, error)
+	ImportSection(collectionID, userID, name string, materials []models.NewMaterial) (string, []string, error)
+	GetSections(collectionID, userID string) ([]models.Section, error)
 	UpdateMaterial(material models.Material) error
-	DeleteMaterial(userID, materialID string) error
-	GetMaterial(materialID string) (models.Material, error)
-	GetMaterials(collectionID string, userID string) ([]models.Material, error)
+	DeleteMaterial(materialID string) error
+	GetMaterial(materialID, userID string) (models.Material, error)
+	GetMaterials(collectionID string, userID string, filter models.MaterialFilter) (models.MaterialList, error)
 	MarkMaterialAsCompleted(userID, materialID string) error
 	MarkMaterialAsNotCompleted(userID, materialID string) error
-	SearchMaterials(query string) ([]models.Material, error)
+	SearchMaterials(search models.SearchQuery, userID string) (models.MaterialList, error)
+	SearchFacets(search models.SearchQuery, userID string) (models.SearchFacets, error)
+	Suggest(query, userID string, limit int) (models.Suggestions, error)
+
+	CreateSavedSearch(userID, name string, query models.SearchQuery) (string, error)
+	GetSavedSearches(userID string) ([]models.SavedSearch, error)
+	GetAllSavedSearches() ([]models.SavedSearch, error)
+	DeleteSavedSearch(searchID, userID string) error
+	CheckSavedSearch(search models.SavedSearch) (int, error)
+	GetNotifications(userID string, unreadOnly bool) ([]models.Notification, error)
+	MarkNotificationRead(notificationID, userID string) error
+
+	GetTypeMaterials(page models.Page) (models.TypeMaterialList, error)
+
+	SetCollectionTags(collectionID string, names []string) error
+	SetMaterialTags(materialID string, names []string) error
+	GetTags(curatedOnly bool) ([]models.Tag, error)
+
+	SaveReview(collectionID, userID string, rating int, text string) error
+	DeleteReview(collectionID, userID string) error
+	GetReviews(collectionID, userID string) ([]models.Review, error)
+
+	CreateComment(materialID, userID, parentID, text string) (string, error)
+	GetComment(commentID string) (models.Comment, error)
+	GetComments(materialID, userID string, page models.Page) (models.CommentList, error)
+	UpdateComment(commentID, text string) error
+	DeleteComment(commentID string) error
+	HideComment(commentID, moderatorID string) error
+
+	CreateFeed(collectionID, userID, url, title string) (string, error)
+	GetFeeds(collectionID, userID string) ([]models.Feed, error)
+	GetAllFeeds() ([]models.Feed, error)
+	DeleteFeed(feedID, collectionID string) error
+	AddFeedEntries(feed models.Feed, entries []models.FeedEntry) (int, error)
+	UpdateFeedStatus(feedID, title, lastError string) error
+
+	GetLinksToCheck(limit int) ([]models.LinkCheck, error)
+	SaveLinkCheck(check models.LinkCheck, nextCheck time.Time) error
+	GetCollectionHealth(collectionID string) (models.CollectionHealth, error)
+
+	GetRecommendationCorpus(userID string) (models.RecommendationCorpus, error)
+	GetCollectionEngagement() ([]models.Engagement, error)
+	ReplaceRelatedCollections(similarities []models.CollectionSimilarity) error
+	GetRelatedCollections(collectionID, userID string, limit int) ([]models.RelatedCollection, error)
+	GetNextCandidates(userID string) ([]models.NextCandidate, error)
+
+	RefreshCollectionRanking(kind string, since time.Time) (int, error)
+	GetRankedCollections(kind, userID string, page models.Page) (models.RankedCollectionList, error)
 
-	GetTypeMaterials() ([]models.TypeMaterial, error)
+	GetAccountArchive(userID string) (models.Archive, error)
+	ImportAccountArchive(userID string, archive models.Archive) (models.ArchiveImportResult, error)
 }
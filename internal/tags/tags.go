@@ -0,0 +1,24 @@
+package tags
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const MaxLength = 50
+
+// Normalize lowercases tag names, joins inner whitespace with dashes and drops
+// empty, overlong and duplicate entries while keeping the original order.
+func Normalize(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.Join(strings.Fields(strings.ToLower(name)), "-")
+		if name == "" || utf8.RuneCountInString(name) > MaxLength || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
@@ -0,0 +1,13 @@
+package tags
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, []string{"golang", "soft-skills", "книги"}, Normalize([]string{" GoLang ", "soft  skills", "", "golang", "Книги"}))
+	assert.Empty(t, Normalize([]string{strings.Repeat("a", MaxLength+1)}))
+	assert.Empty(t, Normalize(nil))
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "collection_roles"
+(
+    collection_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id),
+    role text NOT NULL CHECK (role IN ('viewer', 'editor', 'maintainer')),
+    created_at timestamp(0) without time zone NOT NULL,
+    updated_at timestamp(0) without time zone NOT NULL,
+    PRIMARY KEY (collection_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS collection_roles_user_id_idx ON collection_roles(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE collection_roles;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "tags"
+(
+    id uuid PRIMARY KEY NOT NULL,
+    created_at timestamp(0) without time zone NOT NULL,
+    updated_at timestamp(0) without time zone NOT NULL,
+    name text NOT NULL UNIQUE,
+    curated boolean NOT NULL DEFAULT false -- тег из курируемого списка
+);
+CREATE TABLE IF NOT EXISTS "collection_tags"
+(
+    collection_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    tag_id uuid NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (collection_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS collection_tags_tag_id_idx ON collection_tags(tag_id);
+CREATE TABLE IF NOT EXISTS "material_tags"
+(
+    material_id uuid NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    tag_id uuid NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (material_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS material_tags_tag_id_idx ON material_tags(tag_id);
+INSERT INTO "tags" (id, created_at, updated_at, name, curated)
+VALUES
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a01', NOW(), NOW(), 'golang', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a02', NOW(), NOW(), 'python', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a03', NOW(), NOW(), 'javascript', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a04', NOW(), NOW(), 'databases', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a05', NOW(), NOW(), 'devops', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a06', NOW(), NOW(), 'algorithms', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a07', NOW(), NOW(), 'architecture', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a08', NOW(), NOW(), 'soft-skills', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a09', NOW(), NOW(), 'management', true),
+    ('6b0f0c52-3f4e-4f55-9d3a-0c5e7b1f2a10', NOW(), NOW(), 'english', true);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE material_tags;
+DROP TABLE collection_tags;
+DROP TABLE tags;
+-- +goose StatementEnd
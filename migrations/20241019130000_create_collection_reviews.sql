@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "collection_reviews"
+(
+    collection_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id),
+    created_at timestamp(0) without time zone NOT NULL,
+    updated_at timestamp(0) without time zone NOT NULL,
+    rating smallint NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    text text NOT NULL DEFAULT '',
+    PRIMARY KEY (collection_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE collection_reviews;
+-- +goose StatementEnd
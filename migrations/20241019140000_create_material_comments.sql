@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "material_comments"
+(
+    id uuid NOT NULL PRIMARY KEY,
+    created_at timestamp(0) without time zone NOT NULL,
+    updated_at timestamp(0) without time zone NOT NULL,
+    material_id uuid NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id),
+    parent_id uuid REFERENCES material_comments(id) ON DELETE CASCADE,
+    text text NOT NULL,
+    deleted_at timestamp(0) without time zone,
+    deleted_by uuid REFERENCES users(id)
+);
+CREATE INDEX material_comments_material_id_idx ON material_comments(material_id, created_at) WHERE parent_id IS NULL;
+CREATE INDEX material_comments_parent_id_idx ON material_comments(parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE material_comments;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX collections_name_trgm_idx ON collections USING GIN (name gin_trgm_ops);
+CREATE INDEX materials_name_trgm_idx ON materials USING GIN (name gin_trgm_ops);
+CREATE INDEX tags_name_trgm_idx ON tags USING GIN (name gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX tags_name_trgm_idx;
+DROP INDEX materials_name_trgm_idx;
+DROP INDEX collections_name_trgm_idx;
+DROP EXTENSION IF EXISTS pg_trgm;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS collection_materials_material_id_idx ON collection_materials(material_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX collection_materials_material_id_idx;
+-- +goose StatementEnd
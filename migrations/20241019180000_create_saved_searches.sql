@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "saved_searches"
+(
+    id uuid NOT NULL PRIMARY KEY,
+    created_at timestamp(0) without time zone NOT NULL,
+    updated_at timestamp(0) without time zone NOT NULL,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    query jsonb NOT NULL,
+    checked_at timestamp(0) without time zone NOT NULL
+);
+CREATE INDEX saved_searches_user_id_idx ON saved_searches(user_id);
+
+CREATE TABLE IF NOT EXISTS "notifications"
+(
+    id uuid NOT NULL PRIMARY KEY,
+    created_at timestamp(0) without time zone NOT NULL,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    saved_search_id uuid REFERENCES saved_searches(id) ON DELETE CASCADE,
+    kind text NOT NULL CHECK (kind IN ('collection', 'material')),
+    object_id uuid NOT NULL,
+    name text NOT NULL,
+    read_at timestamp(0) without time zone,
+    UNIQUE (saved_search_id, kind, object_id)
+);
+CREATE INDEX notifications_user_id_idx ON notifications(user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notifications;
+DROP TABLE saved_searches;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_materials ADD COLUMN IF NOT EXISTS completed_at timestamp(0) without time zone;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_materials DROP COLUMN IF EXISTS completed_at;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "collection_sections"
+(
+    id uuid PRIMARY KEY NOT NULL,
+    collection_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    position integer NOT NULL,
+    created_at timestamp(0) without time zone NOT NULL,
+    updated_at timestamp(0) without time zone NOT NULL
+);
+CREATE INDEX IF NOT EXISTS collection_sections_collection_id_idx ON collection_sections(collection_id, position);
+ALTER TABLE collection_materials
+    ADD COLUMN IF NOT EXISTS section_id uuid REFERENCES collection_sections(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS position integer;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE collection_materials DROP COLUMN IF EXISTS position, DROP COLUMN IF EXISTS section_id;
+DROP TABLE collection_sections;
+-- +goose StatementEnd
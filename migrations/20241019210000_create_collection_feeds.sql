@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "collection_feeds"
+(
+    id uuid PRIMARY KEY NOT NULL,
+    collection_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id),
+    url text NOT NULL,
+    title text NOT NULL DEFAULT '',
+    created_at timestamp(0) without time zone NOT NULL,
+    updated_at timestamp(0) without time zone NOT NULL,
+    checked_at timestamp(0) without time zone,
+    last_error text,
+    UNIQUE (collection_id, url)
+);
+CREATE TABLE IF NOT EXISTS "feed_entries"
+(
+    feed_id uuid NOT NULL REFERENCES collection_feeds(id) ON DELETE CASCADE,
+    key text NOT NULL,
+    material_id uuid REFERENCES materials(id) ON DELETE SET NULL,
+    created_at timestamp(0) without time zone NOT NULL,
+    PRIMARY KEY (feed_id, key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE feed_entries;
+DROP TABLE collection_feeds;
+-- +goose StatementEnd
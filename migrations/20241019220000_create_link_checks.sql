@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "link_checks"
+(
+    material_id uuid PRIMARY KEY NOT NULL REFERENCES materials(id) ON DELETE CASCADE,
+    link text NOT NULL,
+    status_code integer NOT NULL DEFAULT 0,
+    redirect_to text,
+    last_error text,
+    failures integer NOT NULL DEFAULT 0,
+    checked_at timestamp(0) without time zone NOT NULL,
+    next_check_at timestamp(0) without time zone NOT NULL
+);
+CREATE INDEX IF NOT EXISTS link_checks_next_check_at_idx ON link_checks (next_check_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE link_checks;
+-- +goose StatementEnd
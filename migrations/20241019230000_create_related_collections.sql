@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "related_collections"
+(
+    collection_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    related_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    score double precision NOT NULL,
+    common_users integer NOT NULL,
+    computed_at timestamp(0) without time zone NOT NULL,
+    PRIMARY KEY (collection_id, related_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE related_collections;
+-- +goose StatementEnd
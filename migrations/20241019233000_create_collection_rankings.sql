@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing joins have no known time, so they only count towards rankings without a window.
+ALTER TABLE user_collections ADD COLUMN IF NOT EXISTS joined_at timestamp(0) without time zone;
+ALTER TABLE user_collections ALTER COLUMN joined_at SET DEFAULT NOW();
+CREATE INDEX IF NOT EXISTS user_collections_joined_at_idx ON user_collections(joined_at);
+CREATE INDEX IF NOT EXISTS user_materials_completed_at_idx ON user_materials(completed_at);
+CREATE TABLE IF NOT EXISTS "collection_rankings"
+(
+    collection_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    kind text NOT NULL,
+    score double precision NOT NULL,
+    joins integer NOT NULL,
+    completions integer NOT NULL,
+    xp_earned bigint NOT NULL,
+    computed_at timestamp(0) without time zone NOT NULL,
+    PRIMARY KEY (kind, collection_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE collection_rankings;
+DROP INDEX IF EXISTS user_materials_completed_at_idx;
+DROP INDEX IF EXISTS user_collections_joined_at_idx;
+ALTER TABLE user_collections DROP COLUMN IF EXISTS joined_at;
+-- +goose StatementEnd
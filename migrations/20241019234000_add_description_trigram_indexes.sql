@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS collections_description_trgm_idx ON collections USING GIN (description gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS materials_description_trgm_idx ON materials USING GIN (description gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX materials_description_trgm_idx;
+DROP INDEX collections_description_trgm_idx;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Inherited links are materials a fork shares with its origin, they grant no role over the material.
+ALTER TABLE collection_materials ADD COLUMN IF NOT EXISTS inherited boolean NOT NULL DEFAULT false;
+UPDATE collection_materials
+SET inherited = true
+FROM collections
+WHERE collections.id = collection_materials.collection_id
+  AND EXISTS (SELECT 1 FROM collection_materials origin
+      WHERE origin.collection_id = collections.forked_from AND origin.material_id = collection_materials.material_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE collection_materials DROP COLUMN IF EXISTS inherited;
+-- +goose StatementEnd